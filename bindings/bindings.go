@@ -0,0 +1,175 @@
+// Package bindings maps the keys of a frontend to controller actions, from a keymap file or the built-in defaults
+package bindings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+)
+
+// Keymap - The keys bound to each action (key names are the frontend's, compared case-insensitively) and how held movement keys repeat
+type Keymap struct {
+	Keys        map[controller.Action][]string
+	RepeatDelay time.Duration // a movement key held this long starts repeating (0 for no repeat)
+	RepeatRate  time.Duration // then repeats this often (the delay if 0)
+}
+
+// keymapFile - The JSON keymap file: keys by action name, e.g. {"keys": {"MoveUp": ["Up", "W", "KP8"]}, "repeat": {"delay": "250ms", "rate": "100ms"}}
+type keymapFile struct {
+	Keys   map[string][]string `json:"keys"`
+	Repeat struct {
+		Delay string `json:"delay"`
+		Rate  string `json:"rate"`
+	} `json:"repeat"`
+}
+
+// Default - Returns the built-in keymap: cursors (or the numeric keypad) move, a letter per command, no repeat
+func Default() *Keymap {
+	return &Keymap{Keys: map[controller.Action][]string{
+		controller.MoveUp:            {"Up", "KP8"},
+		controller.MoveDown:          {"Down", "KP2"},
+		controller.MoveLeft:          {"Left", "KP4"},
+		controller.MoveRight:         {"Right", "KP6"},
+		controller.Undo:              {"Z"},
+		controller.Redo:              {"Y"},
+		controller.Restart:           {"R"},
+		controller.ToggleHints:       {"F"},
+		controller.ToggleAutoplay:    {"A"},
+		controller.ToggleHintBackend: {"B"},
+		controller.ToggleObjective:   {"O"},
+		controller.LoadSolution:      {"L"},
+		controller.SaveSolution:      {"S"},
+		controller.ZoomIn:            {"Equal", "KPAdd"},
+		controller.ZoomOut:           {"Minus", "KPSubtract"},
+		controller.Confirm:           {"Space"},
+	}}
+}
+
+// DefaultPath - Returns the keymap file location under the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sokoban-go", "keys.json"), nil
+}
+
+// Load - Reads a keymap file (see Parse)
+func Load(path string) (*Keymap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	k, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return k, nil
+}
+
+// Parse - Reads a JSON keymap over the defaults: the actions it lists get its keys instead (none unbinds them), the others keep theirs
+func Parse(r io.Reader) (*Keymap, error) {
+	file := keymapFile{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	k := Default()
+	for name, keys := range file.Keys {
+		action, err := controller.ParseAction(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("%v: empty key name", action)
+			}
+		}
+		k.Keys[action] = keys
+	}
+	var err error
+	if k.RepeatDelay, err = parseDuration("delay", file.Repeat.Delay); err != nil {
+		return nil, err
+	}
+	if k.RepeatRate, err = parseDuration("rate", file.Repeat.Rate); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func parseDuration(name, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("repeat %s: %v", name, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("repeat %s: %v is negative", name, d)
+	}
+	return d, nil
+}
+
+// Action - Returns the first action (in controller.Actions order) the key is bound to, false if none
+func (k *Keymap) Action(key string) (controller.Action, bool) {
+	for _, action := range controller.Actions() {
+		for _, bound := range k.Keys[action] {
+			if strings.EqualFold(bound, key) {
+				return action, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Conflict - A key bound to more than one action, only the first of them (in controller.Actions order) fires
+type Conflict struct {
+	Key     string
+	Actions []controller.Action
+}
+
+func (c Conflict) String() string {
+	names := make([]string, len(c.Actions))
+	for i, a := range c.Actions {
+		names[i] = a.String()
+	}
+	return fmt.Sprintf("key %q is bound to %s", c.Key, strings.Join(names, " and "))
+}
+
+// Conflicts - Returns the keys bound to more than one action, by key name
+func (k *Keymap) Conflicts() []Conflict {
+	byKey := map[string]*Conflict{}
+	for _, action := range controller.Actions() {
+		for _, key := range k.Keys[action] {
+			c := byKey[strings.ToLower(key)]
+			if c == nil {
+				c = &Conflict{Key: key}
+				byKey[strings.ToLower(key)] = c
+			}
+			if len(c.Actions) == 0 || c.Actions[len(c.Actions)-1] != action {
+				c.Actions = append(c.Actions, action)
+			}
+		}
+	}
+	conflicts := []Conflict{}
+	for _, c := range byKey {
+		if len(c.Actions) > 1 {
+			conflicts = append(conflicts, *c)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return strings.ToLower(conflicts[i].Key) < strings.ToLower(conflicts[j].Key)
+	})
+	return conflicts
+}
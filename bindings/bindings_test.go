@@ -0,0 +1,199 @@
+package bindings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	k := Default()
+	for _, action := range controller.Actions() {
+		assert.NotEmpty(t, k.Keys[action], action.String())
+	}
+	assert.Empty(t, k.Conflicts())
+	assert.Equal(t, time.Duration(0), k.RepeatDelay)
+
+	action, ok := k.Action("space")
+	assert.True(t, ok)
+	assert.Equal(t, controller.Confirm, action)
+	_, ok = k.Action("Q")
+	assert.False(t, ok)
+}
+
+func TestParse(t *testing.T) {
+	// WASD moves, the letters it takes over move elsewhere, hjkl is left in conflict
+	k, err := Parse(strings.NewReader(`{
+		"keys": {
+			"MoveUp": ["Up", "W", "K"],
+			"MoveDown": ["Down", "S", "J"],
+			"MoveLeft": ["Left", "A", "H"],
+			"MoveRight": ["Right", "D", "l"],
+			"ToggleAutoplay": ["P"],
+			"SaveSolution": ["Enter"],
+			"LoadSolution": []
+		},
+		"repeat": {"delay": "250ms", "rate": "100ms"}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Up", "W", "K"}, k.Keys[controller.MoveUp])
+	assert.Equal(t, []string{"Z"}, k.Keys[controller.Undo])
+	assert.Empty(t, k.Keys[controller.LoadSolution])
+	assert.Equal(t, 250*time.Millisecond, k.RepeatDelay)
+	assert.Equal(t, 100*time.Millisecond, k.RepeatRate)
+	assert.Empty(t, k.Conflicts())
+
+	k.Keys[controller.LoadSolution] = []string{"L"}
+	k.Keys[controller.Confirm] = []string{"Space", "w"}
+	conflicts := k.Conflicts()
+	assert.Equal(t, 2, len(conflicts))
+	assert.Equal(t, `key "l" is bound to MoveRight and LoadSolution`, conflicts[0].String())
+	assert.Equal(t, []controller.Action{controller.MoveUp, controller.Confirm}, conflicts[1].Actions)
+
+	for _, bad := range []string{
+		`{"keys": {"Jump": ["J"]}}`,
+		`{"keys": {"MoveUp": [""]}}`,
+		`{"keys": {"MoveUp": "Up"}}`,
+		`{"repeat": {"delay": "soon"}}`,
+		`{"repeat": {"rate": "-1s"}}`,
+		`{"key": {}}`,
+	} {
+		_, err := Parse(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"keys": {"Undo": ["U", "Backspace"]}}`), 0644))
+	k, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"U", "Backspace"}, k.Keys[controller.Undo])
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"keys": {"Undo": ["U"]`), 0644))
+	_, err = Load(path)
+	assert.ErrorContains(t, err, path)
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestInput(t *testing.T) {
+	k := Default()
+	in := NewInput(k)
+	down := map[string]bool{}
+	pressed := func(key string) bool { return down[key] }
+	start := time.Now()
+	frame := func(ms int) (controller.Action, bool) {
+		return in.Update(start.Add(time.Duration(ms)*time.Millisecond), pressed)
+	}
+
+	// once per key press without repeat, whatever other key is held meanwhile
+	_, ok := frame(0)
+	assert.False(t, ok)
+	down["Left"] = true
+	action, ok := frame(0)
+	assert.True(t, ok)
+	assert.Equal(t, controller.MoveLeft, action)
+	_, ok = frame(1000)
+	assert.False(t, ok)
+	down["A"] = true
+	action, ok = frame(1050)
+	assert.True(t, ok)
+	assert.Equal(t, controller.ToggleAutoplay, action)
+	down["Left"] = false
+	_, ok = frame(1100)
+	assert.False(t, ok)
+	down["A"] = false
+	_, ok = frame(1150)
+	assert.False(t, ok)
+
+	// keys going down in the same frame fire one frame after the other, even once released
+	down["Z"], down["Y"] = true, true
+	action, ok = frame(1200)
+	assert.True(t, ok)
+	assert.Equal(t, controller.Undo, action)
+	down["Z"], down["Y"] = false, false
+	action, ok = frame(1250)
+	assert.True(t, ok)
+	assert.Equal(t, controller.Redo, action)
+	_, ok = frame(1300)
+	assert.False(t, ok)
+
+	// held movement keys repeat after the delay, other keys do not
+	k.RepeatDelay = 200 * time.Millisecond
+	k.RepeatRate = 100 * time.Millisecond
+	down["KP8"] = true
+	fired := 0
+	for ms := 0; ms < 500; ms += 50 {
+		if action, ok := frame(2000 + ms); ok {
+			assert.Equal(t, controller.MoveUp, action)
+			fired++
+		}
+	}
+	assert.Equal(t, 4, fired) // at 0, 200, 300 and 400ms
+
+	// the last movement key pressed repeats, on its own delay
+	down["Left"] = true
+	fired = 0
+	for ms := 0; ms < 300; ms += 50 {
+		if action, ok := frame(2500 + ms); ok {
+			assert.Equal(t, controller.MoveLeft, action)
+			fired++
+		}
+	}
+	assert.Equal(t, 2, fired) // at 0 and 200ms
+	down["KP8"], down["Left"] = false, false
+	frame(2900)
+	down["Z"] = true
+	fired = 0
+	for ms := 0; ms < 500; ms += 50 {
+		if _, ok := frame(3000 + ms); ok {
+			fired++
+		}
+	}
+	assert.Equal(t, 1, fired)
+}
+
+func TestLayout(t *testing.T) {
+	l := NewLayout()
+	key, ok := l.Key("Z")
+	assert.True(t, ok)
+	assert.Equal(t, "z", key)
+	key, _ = l.Key("Left")
+	assert.Equal(t, "left", key)
+
+	// on AZERTY the key in the place of QWERTY W types z, and the one in the place of Z types w
+	l.Learn("z", []string{"W"})
+	key, ok = l.Key("Z")
+	assert.True(t, ok)
+	assert.Equal(t, "w", key)
+	_, ok = l.Key("W")
+	assert.False(t, ok)
+	l.Learn("W", []string{"Z"})
+	key, ok = l.Key("w")
+	assert.True(t, ok)
+	assert.Equal(t, "z", key)
+
+	// several characters in a frame go to the keys named after them first, repeated text teaches nothing
+	l.Learn("ab", []string{"B", "Q"})
+	key, _ = l.Key("A")
+	assert.Equal(t, "q", key)
+	key, _ = l.Key("B")
+	assert.Equal(t, "b", key)
+	l.Learn("zzz", nil)
+	key, _ = l.Key("Z")
+	assert.Equal(t, "w", key)
+
+	// back on QWERTY
+	l.Learn("z", []string{"Z"})
+	key, _ = l.Key("Z")
+	assert.Equal(t, "z", key)
+	key, ok = l.Key("W")
+	assert.True(t, ok)
+	assert.Equal(t, "w", key)
+}
@@ -0,0 +1,70 @@
+package bindings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+)
+
+// Input - Turns the keys held down frame after frame into actions: one when a key goes down, then, for the last movement key pressed and held long enough, one every RepeatRate
+type Input struct {
+	keymap  *Keymap
+	down    map[string]bool     // the keys down last frame (lower case)
+	pending []controller.Action // keys that went down in the same frame fire one per frame
+	held    string              // the movement key repeating, while it stays down
+	action  controller.Action   // its action
+	next    time.Time           // when it repeats
+}
+
+// NewInput - Creates the input of a keymap
+func NewInput(k *Keymap) *Input {
+	return &Input{keymap: k, down: map[string]bool{}}
+}
+
+// Update - Returns the action to fire this frame, false if none. pressed tells whether a key (by name) is down or went down since the last frame,
+// a key bound to several actions fires the first of them (in controller.Actions order)
+func (in *Input) Update(now time.Time, pressed func(key string) bool) (controller.Action, bool) {
+	down := map[string]bool{}
+	for _, action := range controller.Actions() {
+		for _, key := range in.keymap.Keys[action] {
+			k := strings.ToLower(key)
+			if down[k] || !pressed(key) {
+				continue
+			}
+			down[k] = true
+			if in.down[k] {
+				continue
+			}
+			in.pending = append(in.pending, action)
+			in.held = ""
+			if isMove(action) {
+				in.held, in.action = k, action
+				in.next = now.Add(in.keymap.RepeatDelay)
+			}
+		}
+	}
+	in.down = down
+	if !down[in.held] {
+		in.held = ""
+	}
+
+	if len(in.pending) > 0 {
+		action := in.pending[0]
+		in.pending = in.pending[1:]
+		return action, true
+	}
+	if in.held != "" && in.keymap.RepeatDelay > 0 && !now.Before(in.next) {
+		rate := in.keymap.RepeatRate
+		if rate == 0 {
+			rate = in.keymap.RepeatDelay
+		}
+		in.next = now.Add(rate)
+		return in.action, true
+	}
+	return 0, false
+}
+
+func isMove(a controller.Action) bool {
+	return a == controller.MoveUp || a == controller.MoveDown || a == controller.MoveLeft || a == controller.MoveRight
+}
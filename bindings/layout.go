@@ -0,0 +1,76 @@
+package bindings
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout - Learns from the text typed which physical key types each character, so that one character key names follow the keyboard layout
+// (Z undoes on AZERTY as on QWERTY) while the key state itself, held or not, is the physical one
+type Layout struct {
+	typedBy map[rune]string // the physical key (lower case name) typing a character
+	types   map[string]rune // the other way round
+}
+
+// NewLayout - Creates a layout that knows no key yet: every key name is taken as the physical key of the same name until typed
+func NewLayout() *Layout {
+	return &Layout{typedBy: map[rune]string{}, types: map[string]rune{}}
+}
+
+// Learn - Matches the text typed this frame with the keys (by name) that went down this frame: the key named after a character types it,
+// the other characters go to the keys left in order. Text typed while no key went down (a key repeating) teaches nothing
+func (l *Layout) Learn(typed string, keys []string) {
+	left := map[string]bool{}
+	for _, key := range keys {
+		left[strings.ToLower(key)] = true
+	}
+	others := []rune{}
+	for _, r := range strings.ToLower(typed) {
+		if key := string(r); left[key] {
+			l.set(r, key)
+			delete(left, key)
+		} else {
+			others = append(others, r)
+		}
+	}
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if len(others) == 0 {
+			return
+		}
+		if left[key] {
+			l.set(others[0], key)
+			delete(left, key)
+			others = others[1:]
+		}
+	}
+}
+
+// set - Records that key types r, forgetting what each typed before
+func (l *Layout) set(r rune, key string) {
+	if old, ok := l.typedBy[r]; ok {
+		delete(l.types, old)
+	}
+	if old, ok := l.types[key]; ok {
+		delete(l.typedBy, old)
+	}
+	l.typedBy[r] = key
+	l.types[key] = r
+}
+
+// Key - Returns the physical key (lower case name) to check for a key name: for one character, the key typing it once learnt, else the key of that name
+// unless it is known to type another character (false then, the character is on a key not typed yet)
+func (l *Layout) Key(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if utf8.RuneCountInString(name) != 1 {
+		return name, true
+	}
+	r, _ := utf8.DecodeRuneInString(name)
+	if key, ok := l.typedBy[r]; ok {
+		return key, true
+	}
+	if other, ok := l.types[name]; ok && other != r {
+		return "", false
+	}
+	return name, true
+}
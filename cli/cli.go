@@ -0,0 +1,38 @@
+// Package cli holds the command-line subcommands, they run without opening a window
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// command - A subcommand, returns the process exit code
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"render": render,
+	"solve":  solve,
+	"verify": verify,
+}
+
+// IsCommand - Returns true if name is a subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run - Runs the subcommand named by args[0] and returns the process exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		names := []string{}
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(stderr, "usage: sokoban <%s> [arguments]\n", strings.Join(names, "|"))
+		return 2
+	}
+	return commands[args[0]](args[1:], stdout, stderr)
+}
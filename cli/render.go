@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/TheInvader360/sokoban-go/view"
+)
+
+// render - "sokoban render [-level n] [-moves lurd | -solution file] [-scale s] [-delay d] [-sprites file] [-o file] <file>": draws a level to a PNG, or its solution to an animated GIF
+func render(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	levelNumber := flags.Int("level", 1, "level of the pack to draw (1 is the first one)")
+	lurd := flags.String("moves", "", "LURD moves (plain or run-length encoded) to replay into a GIF")
+	solutionPath := flags.String("solution", "", "file holding the LURD moves to replay into a GIF (e.g. one saved by the game)")
+	scale := flags.Int("scale", 2, "pixels per spritesheet pixel")
+	delay := flags.Duration("delay", 150*time.Millisecond, "time each move is shown in the GIF (rounded to 10ms)")
+	sprites := flags.String("sprites", "assets/spritesheet.png", "spritesheet the tiles are taken from")
+	out := flags.String("o", "", "file to write (defaults to level_NN.png, or level_NN.gif with moves)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sokoban render [-level n] [-moves lurd | -solution file] [-scale s] [-delay d] [-sprites file] [-o file] <file or directory>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 || *levelNumber < 1 || *scale < 1 || *delay < 0 || (*lurd != "" && *solutionPath != "") {
+		flags.Usage()
+		return 2
+	}
+	levels, err := loadLevels(flags.Arg(0), *levelNumber)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	l := levels[0]
+	sheet, err := view.LoadSpritesheet(*sprites)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *solutionPath != "" {
+		data, err := os.ReadFile(*solutionPath)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		*lurd = strings.TrimSpace(string(data))
+	}
+	animate := *lurd != ""
+	if *out == "" {
+		*out = fmt.Sprintf("level_%02d.png", *levelNumber)
+		if animate {
+			*out = fmt.Sprintf("level_%02d.gif", *levelNumber)
+		}
+	}
+
+	var write func(w io.Writer) error
+	if animate {
+		moves, err := model.DecodeMoves(*lurd)
+		if err != nil {
+			fmt.Fprintf(stderr, "invalid moves: %v\n", err)
+			return 2
+		}
+		anim, err := view.SolutionGIF(l, model.Directions(moves), sheet, *scale, int(*delay/(10*time.Millisecond)))
+		if err != nil {
+			fmt.Fprintf(stdout, "Level %d%s: %v\n", *levelNumber, levelTitle(l), err)
+			return 1
+		}
+		write = func(w io.Writer) error { return gif.EncodeAll(w, anim) }
+	} else {
+		img := view.BoardImage(model.NewBoard(l.MapData, l.Width, l.Height), sheet, *scale)
+		write = func(w io.Writer) error { return png.Encode(w, img) }
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Level %d%s: %s\n", *levelNumber, levelTitle(l), *out)
+	return 0
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	path := writePack(t, pack)
+	dir := t.TempDir()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	// a level to PNG
+	out := filepath.Join(dir, "corridor.png")
+	assert.Equal(t, 0, Run([]string{"render", "-sprites", "../assets/spritesheet.png", "-scale", "1", "-o", out, path}, stdout, stderr))
+	assert.Equal(t, "Level 1 (Corridor): "+out+"\n", stdout.String())
+	f, err := os.Open(out)
+	assert.NoError(t, err)
+	img, err := png.Decode(f)
+	f.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, 7*16, img.Bounds().Dx())
+	assert.Equal(t, 3*16, img.Bounds().Dy())
+
+	// a solution to GIF, from the command line then from a file
+	out = filepath.Join(dir, "corridor.gif")
+	stdout.Reset()
+	assert.Equal(t, 0, Run([]string{"render", "-sprites", "../assets/spritesheet.png", "-moves", "r2R", "-delay", "200ms", "-o", out, path}, stdout, stderr))
+	assert.Equal(t, "Level 1 (Corridor): "+out+"\n", stdout.String())
+	f, err = os.Open(out)
+	assert.NoError(t, err)
+	anim, err := gif.DecodeAll(f)
+	f.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{20, 20, 20, 120}, anim.Delay)
+	assert.Equal(t, 7*16*2, anim.Config.Width)
+
+	solution := filepath.Join(dir, "level_02.lurd")
+	assert.NoError(t, os.WriteFile(solution, []byte("rdL\n"), 0644))
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"render", "-sprites", "../assets/spritesheet.png", "-level", "2", "-solution", solution, "-o", out, path}, stdout, stderr))
+	assert.Equal(t, "Level 2 (Dead corner): move 3 (L): box blocked (wall)\n", stdout.String())
+}
+
+func TestRenderUsage(t *testing.T) {
+	path := writePack(t, pack)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	out := filepath.Join(t.TempDir(), "out.gif")
+	assert.Equal(t, 2, Run([]string{"render"}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"render", "-scale", "0", path}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"render", "-moves", "r", "-solution", "x.lurd", path}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"render", "-level", "3", path}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"render", "-sprites", "missing.png", path}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"render", "-sprites", "../assets/spritesheet.png", "-moves", "x", "-o", out, path}, stdout, stderr))
+	assert.True(t, IsCommand("render"))
+}
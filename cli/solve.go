@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/TheInvader360/sokoban-go/solver"
+)
+
+// objectives - Values of the solve -objective flag
+var objectives = map[string]model.Objective{
+	"pushes":       model.ObjectivePushes,
+	"moves":        model.ObjectiveMoves,
+	"pushes-moves": model.ObjectivePushesMoves,
+}
+
+// solve - "sokoban solve [-level n] [-solver board|astar|bidir] [-objective pushes|moves|pushes-moves] [-timeout d] [-workers n] <file>": solves the levels of a pack and prints their solutions in LURD notation
+func solve(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("solve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	levelNumber := flags.Int("level", 0, "only solve this level of the pack (1 is the first one)")
+	backend := flags.String("solver", "board", "search to run: board (the hints search), astar (optimal for -objective) or bidir (push-optimal, forward and backward)")
+	objectiveName := flags.String("objective", "pushes", "what the astar solver minimises: pushes, moves or pushes-moves (fewest pushes, then fewest moves)")
+	timeout := flags.Duration("timeout", 0, "give up on a level after this long (0 for no limit)")
+	workers := flags.Int("workers", runtime.NumCPU(), "goroutines the astar solver expands states on (the board search runs on one)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sokoban solve [-level n] [-solver board|astar|bidir] [-objective pushes|moves|pushes-moves] [-timeout d] [-workers n] <file or directory>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	objective, ok := objectives[*objectiveName]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown objective %q\n", *objectiveName)
+		flags.Usage()
+		return 2
+	}
+	if objective != model.ObjectivePushes && *backend != "astar" {
+		fmt.Fprintf(stderr, "-objective %s needs -solver astar\n", *objectiveName)
+		flags.Usage()
+		return 2
+	}
+	solveLevel := model.SolveLevel
+	switch *backend {
+	case "board":
+	case "astar":
+		solveLevel = func(ctx context.Context, l model.Level) (*model.Solution, error) {
+			return solver.SolveLevel(ctx, l, solver.Options{Workers: *workers, Objective: objective})
+		}
+	case "bidir":
+		solveLevel = func(ctx context.Context, l model.Level) (*model.Solution, error) {
+			return solver.SolveLevel(ctx, l, solver.Options{Bidirectional: true})
+		}
+	default:
+		fmt.Fprintf(stderr, "unknown solver %q\n", *backend)
+		flags.Usage()
+		return 2
+	}
+
+	levels, err := loadLevels(flags.Arg(0), *levelNumber)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	exitCode := 0
+	for n, l := range levels {
+		if *levelNumber != 0 {
+			n = *levelNumber - 1
+		}
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if *timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), *timeout)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
+		}
+		s, err := solveLevel(ctx, l)
+		cancel()
+		fmt.Fprintf(stdout, "Level %d%s: ", n+1, levelTitle(l))
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(stdout, "gave up after %v\n", *timeout)
+			exitCode = 1
+		} else if err != nil {
+			fmt.Fprintln(stdout, err)
+			exitCode = 1
+		} else {
+			fmt.Fprintln(stdout, "solved")
+			fmt.Fprintf(stdout, "  LURD   : %s\n", model.FormatMoves(s.Moves))
+			fmt.Fprintf(stdout, "  Moves  : %d\n", len(s.Moves))
+			fmt.Fprintf(stdout, "  Pushes : %d\n", s.Pushes)
+			if *backend == "bidir" {
+				fmt.Fprintf(stdout, "  Met at : push %d\n", s.Meet)
+			}
+		}
+		fmt.Fprintf(stdout, "  Time   : %v\n", s.Duration)
+		fmt.Fprintf(stdout, "  Boards : %d\n", s.Boards)
+	}
+	return exitCode
+}
+
+// loadLevels - Loads a pack, keeping only the given level if n is not 0
+func loadLevels(path string, n int) ([]model.Level, error) {
+	levels, err := model.LoadLevels(path)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return levels, nil
+	}
+	if n < 1 || n > len(levels) {
+		return nil, fmt.Errorf("%s: no level %d (the pack has %d levels)", path, n, len(levels))
+	}
+	return levels[n-1 : n], nil
+}
+
+func levelTitle(l model.Level) string {
+	if l.Title == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", l.Title)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pack = `#######
+#@ $ .#
+#######
+Title: Corridor
+
+#####
+#@ .#
+#$  #
+#####
+Title: Dead corner
+`
+
+func writePack(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "pack.xsb")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestSolve(t *testing.T) {
+	path := writePack(t, pack)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	// a solvable level
+	assert.Equal(t, 0, Run([]string{"solve", "-level", "1", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n")
+	assert.Contains(t, stdout.String(), "  LURD   : rRR\n")
+	assert.Contains(t, stdout.String(), "  Moves  : 3\n")
+	assert.Contains(t, stdout.String(), "  Pushes : 2\n")
+	assert.Contains(t, stdout.String(), "  Boards : ")
+
+	// the whole pack, with an unsolvable level
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n")
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): no solution found\n")
+
+	// the push-optimal solver
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", "-solver", "astar", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n  LURD   : rRR\n")
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): no solution\n")
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", "-solver", "astar", "-workers", "4", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n  LURD   : rRR\n")
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", "-solver", "astar", "-objective", "moves", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n  LURD   : rRR\n  Moves  : 3\n")
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", "-solver", "bidir", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n  LURD   : rRR\n  Moves  : 3\n  Pushes : 2\n  Met at : push 2\n")
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): no solution\n")
+
+	// out of time
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"solve", "-timeout", "1ns", "-level", "1", path}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): gave up after 1ns\n")
+}
+
+func TestSolveUsage(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	assert.Equal(t, 2, Run([]string{"solve"}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"solve", "-level", "3", writePack(t, pack)}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"solve", filepath.Join(t.TempDir(), "missing.xsb")}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"solve", writePack(t, "; notes, no level\n")}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "no levels found")
+	assert.Equal(t, 2, Run([]string{"solve", "-solver", "bfs", writePack(t, pack)}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"solve", "-solver", "astar", "-objective", "boxes", writePack(t, pack)}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"solve", "-solver", "bidir", "-objective", "moves", writePack(t, pack)}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"unknown"}, stdout, stderr))
+	assert.False(t, IsCommand("unknown"))
+	assert.True(t, IsCommand("solve"))
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// verify - "sokoban verify [-level n] <pack> <solutions>": replays the solutions of a pack and reports the first illegal move of each, or whether it solves its level
+func verify(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	levelNumber := flags.Int("level", 0, "only verify this level of the pack (1 is the first one)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sokoban verify [-level n] <file or directory> <solutions>")
+		fmt.Fprintln(stderr, "solutions is a directory of level_NN.lurd files (as saved by the game), or a file with the LURD solution of every level on its own line (of the given level only with -level)")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+	levels, err := loadLevels(flags.Arg(0), *levelNumber)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	solutions, err := loadSolutions(flags.Arg(1), *levelNumber, len(levels))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	exitCode := 0
+	for n, l := range levels {
+		if *levelNumber != 0 {
+			n = *levelNumber - 1
+		}
+		fmt.Fprintf(stdout, "Level %d%s: ", n+1, levelTitle(l))
+		lurd, ok := solutions[n]
+		if !ok {
+			fmt.Fprintln(stdout, "no solution to verify")
+			exitCode = 1
+			continue
+		}
+		v, err := verifyLevel(l, lurd)
+		switch {
+		case err != nil:
+			fmt.Fprintln(stdout, err)
+			exitCode = 1
+		case !v.Complete:
+			fmt.Fprintln(stdout, "not solved")
+			exitCode = 1
+		default:
+			fmt.Fprintln(stdout, "solved")
+		}
+		if v != nil {
+			fmt.Fprintf(stdout, "  Moves  : %d\n", len(v.Moves))
+			fmt.Fprintf(stdout, "  Pushes : %d\n", v.Pushes)
+		}
+	}
+	return exitCode
+}
+
+// verifyLevel - Verifies a LURD solution (plain or run-length encoded) on the level, a letter of the wrong case (a walk written as a push or the other way round) is an error too
+func verifyLevel(l model.Level, lurd string) (*model.Verification, error) {
+	moves, err := model.DecodeMoves(lurd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid solution: %v", err)
+	}
+	v, err := model.Verify(l, model.Directions(moves))
+	if err != nil {
+		return v, err
+	}
+	for i, mv := range v.Moves {
+		if mv.Push != moves[i].Push {
+			return v, fmt.Errorf("move %d (%v): push expected to be %t", i+1, moves[i], mv.Push)
+		}
+	}
+	return v, nil
+}
+
+// loadSolutions - Reads the solutions to verify by level index (0 is the first level of the pack), from a directory of level_NN.lurd files or from a file (see verify)
+func loadSolutions(path string, levelNumber, levels int) (map[int]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	solutions := map[int]string{}
+	if info.IsDir() {
+		for i := 0; i < levels; i++ {
+			n := i
+			if levelNumber != 0 {
+				n = levelNumber - 1
+			}
+			data, err := os.ReadFile(filepath.Join(path, fmt.Sprintf("level_%02d.lurd", n+1)))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			solutions[n] = strings.TrimSpace(string(data))
+		}
+		return solutions, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if levelNumber != 0 {
+		if len(lines) != 1 {
+			return nil, fmt.Errorf("%s: holds %d solutions, expected the one of level %d", path, len(lines), levelNumber)
+		}
+		solutions[levelNumber-1] = lines[0]
+		return solutions, nil
+	}
+	if len(lines) > levels {
+		return nil, fmt.Errorf("%s: holds %d solutions for %d levels", path, len(lines), levels)
+	}
+	for n, line := range lines {
+		solutions[n] = line
+	}
+	return solutions, nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	path := writePack(t, pack)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	// a file of solutions, one per level
+	solutions := filepath.Join(t.TempDir(), "pack.lurd")
+	assert.NoError(t, os.WriteFile(solutions, []byte("r2R\nrdL\n"), 0644))
+	assert.Equal(t, 1, Run([]string{"verify", path, solutions}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): solved\n  Moves  : 3\n  Pushes : 2\n")
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): move 3 (L): box blocked (wall)\n  Moves  : 2\n  Pushes : 0\n")
+
+	// a directory saved by the game, with a wrong push letter and a level left out
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "level_01.lurd"), []byte("rrR\n"), 0644))
+	stdout.Reset()
+	assert.Equal(t, 1, Run([]string{"verify", path, dir}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 1 (Corridor): move 2 (r): push expected to be true\n")
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): no solution to verify\n")
+
+	// one level, not solved then solved
+	stdout.Reset()
+	assert.NoError(t, os.WriteFile(solutions, []byte("rR"), 0644))
+	assert.Equal(t, 1, Run([]string{"verify", "-level", "1", path, solutions}, stdout, stderr))
+	assert.Equal(t, "Level 1 (Corridor): not solved\n  Moves  : 2\n  Pushes : 1\n", stdout.String())
+	stdout.Reset()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "level_01.lurd"), []byte("rRR\n"), 0644))
+	assert.Equal(t, 0, Run([]string{"verify", "-level", "1", path, dir}, stdout, stderr))
+	assert.Equal(t, "Level 1 (Corridor): solved\n  Moves  : 3\n  Pushes : 2\n", stdout.String())
+}
+
+func TestVerifyUsage(t *testing.T) {
+	path := writePack(t, pack)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	solutions := filepath.Join(t.TempDir(), "pack.lurd")
+	assert.NoError(t, os.WriteFile(solutions, []byte("rRR\nx\nr\n"), 0644))
+	assert.Equal(t, 2, Run([]string{"verify", path}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"verify", path, filepath.Join(t.TempDir(), "missing.lurd")}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"verify", path, solutions}, stdout, stderr))
+	assert.Equal(t, 2, Run([]string{"verify", "-level", "1", path, solutions}, stdout, stderr))
+
+	// a solution that is not LURD
+	assert.NoError(t, os.WriteFile(solutions, []byte("rRR\nx\n"), 0644))
+	assert.Equal(t, 1, Run([]string{"verify", path, solutions}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Level 2 (Dead corner): invalid solution: ")
+	assert.True(t, IsCommand("verify"))
+}
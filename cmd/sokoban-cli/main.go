@@ -0,0 +1,12 @@
+// sokoban-cli runs the headless subcommands (e.g. "solve") without linking OpenGL, for machines without a display
+package main
+
+import (
+	"os"
+
+	"github.com/TheInvader360/sokoban-go/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
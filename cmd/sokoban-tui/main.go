@@ -0,0 +1,97 @@
+// sokoban-tui plays the game in a terminal, without OpenGL: over SSH or in a container with no display
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/launch"
+	"github.com/TheInvader360/sokoban-go/tui"
+	"golang.org/x/term"
+)
+
+var (
+	flags   = launch.RegisterFlags(flag.CommandLine)
+	logPath = flag.String("log", os.DevNull, "file the game messages go to (they would garble the screen)")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("sokoban-tui needs a terminal")
+	}
+	keymap, err := launch.LoadKeymap(flags.Keys, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	// the game messages go to the log, the screen gets stdout
+	log, err := os.OpenFile(*logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+	m, c, err := launch.Start(flags, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.SaveGame(); err != nil {
+			fmt.Fprintf(os.Stderr, "Save game failed: %v\n", err)
+		}
+	}()
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(os.Stdin.Fd()), state)
+	fmt.Fprint(os.Stdout, "\x1b[?1049h\x1b[?25l") // alternate screen, hidden cursor
+	defer fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string, 16)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, key := range tui.ParseKeys(buf[:n]) {
+				keys <- key
+			}
+		}
+	}()
+
+	// Main game loop
+	screen := tui.NewScreen(m, keymap)
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok || key == "Escape" || key == "Ctrl+C" {
+				return nil
+			}
+			if action, ok := keymap.Action(key); ok {
+				c.HandleInput(action)
+			}
+		case <-tick.C:
+			c.Update()
+			m.Update()
+			frame := strings.ReplaceAll(screen.Frame(c.ShowFreeSpace), "\r\n", "\x1b[K\r\n")
+			fmt.Fprint(os.Stdout, "\x1b[H"+frame+"\x1b[J")
+		}
+	}
+}
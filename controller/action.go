@@ -0,0 +1,54 @@
+package controller
+
+import "fmt"
+
+// Action - A player command, whatever the frontend it comes from (keyboard, terminal, network client or test)
+type Action int
+
+const (
+	MoveUp Action = iota
+	MoveDown
+	MoveLeft
+	MoveRight
+	Undo
+	Redo
+	Restart
+	ToggleHints       // show or hide the hints and free space
+	ToggleAutoplay    // play the hinted moves, one per tick
+	ToggleHintBackend // board search or solver
+	ToggleObjective   // what the solver minimises
+	LoadSolution
+	SaveSolution
+	ZoomIn  // larger board tiles, the view scrolls to follow the player once the board does not fit
+	ZoomOut // smaller board tiles
+	Confirm // go on to the next level, or start a new game once all are complete
+)
+
+var actionNames = [...]string{"MoveUp", "MoveDown", "MoveLeft", "MoveRight", "Undo", "Redo", "Restart", "ToggleHints", "ToggleAutoplay", "ToggleHintBackend", "ToggleObjective", "LoadSolution", "SaveSolution", "ZoomIn", "ZoomOut", "Confirm"}
+
+// String - Returns the action name
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return "Unknown"
+	}
+	return actionNames[a]
+}
+
+// Actions - Returns every action, in order
+func Actions() []Action {
+	actions := make([]Action, len(actionNames))
+	for i := range actions {
+		actions[i] = Action(i)
+	}
+	return actions
+}
+
+// ParseAction - Returns the action with the given name (see String)
+func ParseAction(name string) (Action, error) {
+	for i, n := range actionNames {
+		if n == name {
+			return Action(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action %q", name)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActions(t *testing.T) {
+	actions := Actions()
+	assert.Equal(t, MoveUp, actions[0])
+	assert.Equal(t, Confirm, actions[len(actions)-1])
+	assert.Equal(t, "ToggleHintBackend", ToggleHintBackend.String())
+	assert.Equal(t, "Unknown", Action(-1).String())
+	assert.Equal(t, "Unknown", Action(len(actions)).String())
+	for _, a := range actions {
+		parsed, err := ParseAction(a.String())
+		assert.NoError(t, err)
+		assert.Equal(t, a, parsed)
+	}
+	_, err := ParseAction("Jump")
+	assert.EqualError(t, err, `unknown action "Jump"`)
+}
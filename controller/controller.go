@@ -2,9 +2,12 @@ package controller
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
-	pixelgl "github.com/gopxl/pixel/v2"
 	"github.com/TheInvader360/sokoban-go/direction"
 	"github.com/TheInvader360/sokoban-go/model"
 )
@@ -12,53 +15,86 @@ import (
 type Controller struct {
 	m *model.Model
 	ShowFreeSpace bool
+	SolutionDir string
+	SavePath string
+	Out io.Writer // where the game messages go (os.Stdout if nil)
 	autoplay bool
 	autoTime *time.Ticker
+	HintTimeout time.Duration // time budget of a hint search (0 for none)
+	search *hintSearch // the hint search running in the background, if any
+	plan []direction.Direction // solver backend solution from the current position
+	planned bool
 }
 
 // NewController - Creates a controller
 func NewController(m *model.Model) *Controller {
 	c := Controller{
 		m: m,
+		SolutionDir: "solutions",
+		HintTimeout: 10 * time.Second,
 	}
 
 	return &c
 }
 
+// printf - Prints a game message to Out
+func (c *Controller) printf(format string, a ...interface{}) {
+	out := c.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	fmt.Fprintf(out, format, a...)
+}
+
 // StartNewGame - Starts a new game at level 1
 func (c *Controller) StartNewGame() {
 	c.m.LM.Reset()
 	c.tryStartNextLevel()
 }
 
-// HandleInput - Handles user input as appropriate (game state dependent behaviour)
-func (c *Controller) HandleInput(key pixelgl.Button) {
+// HandleInput - Handles a player action as appropriate (game state dependent behaviour)
+func (c *Controller) HandleInput(action Action) {
+	c.m.Notice = ""
+	if action == ZoomIn || action == ZoomOut {
+		c.zoom(action == ZoomIn)
+		return
+	}
 	switch c.m.State {
 	case model.StatePlaying:
-		switch key {
-		case pixelgl.KeyUp:
+		switch action {
+		case MoveUp:
 			c.tryMovePlayer(direction.U)
-		case pixelgl.KeyDown:
+		case MoveDown:
 			c.tryMovePlayer(direction.D)
-		case pixelgl.KeyLeft:
+		case MoveLeft:
 			c.tryMovePlayer(direction.L)
-		case pixelgl.KeyRight:
+		case MoveRight:
 			c.tryMovePlayer(direction.R)
-		case pixelgl.KeyF:
+		case ToggleHints:
 			c.toggleShowFreeSpace()
-		case pixelgl.KeyZ:
+		case Undo:
 			c.tryUndoLastMove()
-		case pixelgl.KeyR:
+		case Redo:
+			c.tryRedoMove()
+		case Restart:
 			c.restartLevel()
-		case pixelgl.KeyA:
+		case ToggleAutoplay:
 			c.toggleAutoplay()
+		case LoadSolution:
+			c.tryLoadSolution()
+		case ToggleHintBackend:
+			c.toggleHintBackend()
+		case ToggleObjective:
+			c.toggleObjective()
 		}
 	case model.StateLevelComplete:
-		if key == pixelgl.KeySpace {
+		if action == Confirm {
 			c.tryStartNextLevel()
+		} else if action == SaveSolution {
+			c.trySaveSolution()
 		}
 	case model.StateGameComplete:
-		if key == pixelgl.KeySpace {
+		if action == Confirm {
 			c.StartNewGame()
 		}
 	}
@@ -75,6 +111,7 @@ func (c *Controller) Autoplay() {
 	}
 }
 
+// toggleAutoplay - Starts or stops autoplay, Update then plays a hinted move every tick
 func (c *Controller) toggleAutoplay() {
 	if (c.autoplay) { 
 		c.autoplay = false
@@ -83,32 +120,37 @@ func (c *Controller) toggleAutoplay() {
 		c.autoplay = true
 		
 		c.autoTime = time.NewTicker(500 * time.Millisecond)
+	}
+}
 
-		go func() {
-			for {
-				if (!c.autoplay) { return }
-				select {
-					case <-c.autoTime.C:
-						c.Autoplay()
-				}
-			}
-		}()
+// Update - Applies the hints of a finished background search and plays autoplay moves (called once per main game loop iteration)
+func (c *Controller) Update() {
+	c.pollSearch()
+	if c.autoplay {
+		select {
+		case <-c.autoTime.C:
+			c.Autoplay()
+		default:
+		}
+	}
+}
+
+// zoom - Zooms the board in or out a step, up to model.MaxZoom steps from the size fitting it
+func (c *Controller) zoom(in bool) {
+	if in && c.m.Zoom < model.MaxZoom {
+		c.m.Zoom++
+	} else if !in && c.m.Zoom > -model.MaxZoom {
+		c.m.Zoom--
 	}
 }
 
 // toggle show/hide Free Space
 func (c *Controller) toggleShowFreeSpace() {
-	if (c.ShowFreeSpace) {
-		c.ShowFreeSpace = false
-	} else {
-		c.ShowFreeSpace = true
-		c.m.Board = c.m.Board.GetBoard(c.m.Boards)
-		c.m.Board.CheckEveryBoxMoveFromPlayer(c.m.Boards)
-	}
+	c.ShowFreeSpace = !c.ShowFreeSpace
 }
 
-// tryMovePlayer - Move player (and an adjacent box where appropriate) in the specified direction if possible. Check for board completion (and handle appropriately) if a box is moved
-func (c *Controller) tryMovePlayer(dir direction.Direction) {
+// tryMovePlayer - Move player (and an adjacent box where appropriate) in the specified direction if possible. Check for board completion (and handle appropriately) if a box is moved. Returns true if the player moved
+func (c *Controller) tryMovePlayer(dir direction.Direction) bool {
 	lastX := c.m.Board.Player.X
 	lastY := c.m.Board.Player.Y
 	targetX := lastX
@@ -134,72 +176,103 @@ func (c *Controller) tryMovePlayer(dir direction.Direction) {
 	targetCell := c.m.Board.Get(targetX, targetY)
 
 	if targetCell.TypeOf == model.CellTypeWall {
-		fmt.Printf("%v: Player blocked (wall)\n", dir)
+		c.printf("%v: Player blocked (wall)\n", dir)
+		return false
 	} else {
 		if targetCell.HasBox {
 			nextCell := c.m.Board.Get(nextX, nextY)
 			if nextCell.TypeOf == model.CellTypeWall {
-				fmt.Printf("%v: Box blocked (wall)\n", dir)
+				c.printf("%v: Box blocked (wall)\n", dir)
+				return false
 			} else if nextCell.HasBox {
-				fmt.Printf("%v: Box blocked (box)\n", dir)
+				c.printf("%v: Box blocked (box)\n", dir)
+				return false
 			} else {
 				c.m.Moves++
-				c.m.Board = c.m.Board.MoveBoxAndCheck(targetX,targetY,dir,c.m.Boards)
-				c.m.LastMove = model.NewLastMove(lastX,lastY,targetX,targetY,nextX,nextY,c.m.LastMove)
-				fmt.Printf("%v: Player moved (push)\n", dir)
-				c.m.Board.CheckEveryBoxMoveFromPlayer(c.m.Boards)
+				c.m.Board = c.m.Board.Duplicate()
+				c.m.Board.MoveBox(targetX,targetY,dir)
+				c.m.LastMove = model.NewLastMove(lastX,lastY,targetX,targetY,nextX,nextY,dir,c.m.LastMove)
+				c.followRedoMove(dir)
+				c.followPlan(dir)
+				c.printf("%v: Player moved (push)\n", dir)
+				c.updateHints()
 				if c.m.Board.IsComplete() {
 					c.m.State = model.StateLevelComplete
-					fmt.Print("*** Level complete! ***\n(space key to continue)\n")
+					c.printf("*** Level complete! ***\n(space key to continue)\n")
+					c.trySaveGame()
 				}
 			}
 		} else {
 			c.m.Moves++
-			c.m.LastMove = model.NewLastMove(lastX,lastY,-1,-1,-1,-1,c.m.LastMove)
+			c.m.LastMove = model.NewLastMove(lastX,lastY,-1,-1,-1,-1,dir,c.m.LastMove)
+			c.followRedoMove(dir)
+			c.followPlan(dir)
 			c.m.Board.Player.X = targetX
 			c.m.Board.Player.Y = targetY
-			c.m.Board.CheckEveryBoxMoveFromPlayer(c.m.Boards)
-			fmt.Printf("%v: Player moved (clear)\n", dir)
+			c.updateHints()
+			c.printf("%v: Player moved (clear)\n", dir)
 		}
 	}
+	return true
 }
 
+// tryUndoLastMove - Steps back the last move (putting back the pushed box if any) and keeps it for redo
 func (c *Controller) tryUndoLastMove() {
-	if c.m.LastMove == nil {
+	lastMove := c.m.LastMove
+	if lastMove == nil {
 		return
 	}
-	c.m.Board = c.m.Board.Duplicate()
-	c.m.Board.Player.X = c.m.LastMove.LastX
-	c.m.Board.Player.Y = c.m.LastMove.LastY
+	if lastMove.IsPush() {
+		c.m.Board = c.m.Board.Duplicate()
+		c.m.Board.PlaceBox(lastMove.LastNextX,lastMove.LastNextY,lastMove.LastTargetX,lastMove.LastTargetY)
+	}
+	c.m.Board.Player.X = lastMove.LastX
+	c.m.Board.Player.Y = lastMove.LastY
 	c.m.Moves--
-	if c.m.LastMove.LastTargetX != -1 {
-		lastCell := c.m.Board.Get(c.m.LastMove.LastTargetX,c.m.LastMove.LastTargetY)
-		nextCell := c.m.Board.Get(c.m.LastMove.LastNextX,c.m.LastMove.LastNextY)
-		lastCell.HasBox = true
-		nextCell.HasBox = false
-		lastCell.Box = nextCell.Box
-		c.m.Board.Boxes[lastCell.Box].X = c.m.LastMove.LastTargetX
-		c.m.Board.Boxes[lastCell.Box].Y = c.m.LastMove.LastTargetY
-		c.m.Board = c.m.Board.GetBoard(c.m.Boards)
+	c.m.LastMove = lastMove.PreviousMove
+	c.m.RedoMove = model.NewLastMove(lastMove.LastX,lastMove.LastY,lastMove.LastTargetX,lastMove.LastTargetY,lastMove.LastNextX,lastMove.LastNextY,lastMove.Dir,c.m.RedoMove)
+	c.printf("Player undo last moved\n")
+
+	c.resetPlan()
+	c.updateHints()
+}
+
+// tryRedoMove - Plays again the last undone move
+func (c *Controller) tryRedoMove() {
+	if c.m.RedoMove == nil {
+		return
 	}
-	c.m.LastMove = c.m.LastMove.PreviousMove
-	fmt.Printf("Player undo last moved\n")
+	c.printf("Player redo move\n")
+	c.tryMovePlayer(c.m.RedoMove.Dir)
+}
 
-	c.m.Board.CheckEveryBoxMoveFromPlayer(c.m.Boards)
+// followRedoMove - Keeps the redo history while the moves played match it, drops it as soon as they diverge
+func (c *Controller) followRedoMove(dir direction.Direction) {
+	if c.m.RedoMove != nil && c.m.RedoMove.Dir == dir {
+		c.m.RedoMove = c.m.RedoMove.PreviousMove
+	} else {
+		c.m.RedoMove = nil
+	}
 }
 
 func (c *Controller) loadLevel() {
 	autoplay := c.autoplay
 	if c.autoplay { c.toggleAutoplay() }
+	c.stopSearch()
 	l := c.m.LM.GetCurrentLevel()
 	c.m.Board = model.NewBoard(l.MapData, l.Width, l.Height)
-	c.m.Boards = make(map[string]*model.Board)
+	if c.m.Boards == nil {
+		c.m.Boards = model.NewTable(model.DefaultTableBytes)
+	} else {
+		c.m.Boards.Reset()
+	}
 	c.m.LastMove = nil
+	c.m.RedoMove = nil
 	c.m.Moves = 0
-	start := time.Now()		
-	c.m.Board.CheckEveryBoxMoveFromPlayer(c.m.Boards)
-	c.m.SolveDuration = time.Now().Sub(start)
-	c.m.BestMoves = c.m.Board.GetBestPosition().BestLength
+	c.m.BestMoves = 0
+	c.m.Zoom = 0 // each level starts at the size that fits it
+	c.resetPlan()
+	c.updateHints()
 	c.m.State = model.StatePlaying
 	if autoplay { c.toggleAutoplay() }
 }
@@ -209,15 +282,104 @@ func (c *Controller) tryStartNextLevel() {
 	if c.m.LM.HasNextLevel() {
 		c.m.LM.ProgressToNextLevel()
 		c.loadLevel()
-		fmt.Printf("Start level %d\n", c.m.LM.GetCurrentLevelNumber())
+		c.printf("Start level %d\n", c.m.LM.GetCurrentLevelNumber())
 	} else {
 		c.m.State = model.StateGameComplete
-		fmt.Print("*** GAME COMPLETE! ***\n(space key to restart)\n")
+		c.printf("*** GAME COMPLETE! ***\n(space key to restart)\n")
 	}
 }
 
 // restartLevel - Resets the game board to the current level's starting state
 func (c *Controller) restartLevel() {
 	c.loadLevel()
-	fmt.Printf("Restart level %d\n", c.m.LM.GetCurrentLevelNumber())
+	c.printf("Restart level %d\n", c.m.LM.GetCurrentLevelNumber())
+}
+
+// solutionPath - Returns the file the current level's solution is saved to and loaded from, in a directory of its own for a level pack (see GetPackName)
+func (c *Controller) solutionPath() string {
+	return filepath.Join(c.SolutionDir, c.m.LM.GetPackName(), fmt.Sprintf("level_%02d.lurd", c.m.LM.GetCurrentLevelNumber()))
+}
+
+// SaveSolution - Writes the moves played on the current level to a file in LURD notation (lowercase walk, uppercase push)
+func (c *Controller) SaveSolution(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(model.FormatMoves(c.m.GetMoves())+"\n"), 0644)
+}
+
+// ReplaySolution - Restarts the current level and replays the given LURD moves (plain or run-length encoded), stopping at the first move that does not match the board
+func (c *Controller) ReplaySolution(lurd string) error {
+	moves, err := model.DecodeMoves(lurd)
+	if err != nil {
+		return err
+	}
+	c.loadLevel()
+	for i, mv := range moves {
+		if c.m.State != model.StatePlaying {
+			return fmt.Errorf("move %d (%v): level already complete", i+1, mv)
+		}
+		if pushing := c.m.Board.IsPush(mv.Dir); pushing != mv.Push {
+			return fmt.Errorf("move %d (%v): push expected to be %t", i+1, mv, pushing)
+		}
+		if !c.tryMovePlayer(mv.Dir) {
+			return fmt.Errorf("move %d (%v): player blocked", i+1, mv)
+		}
+	}
+	return nil
+}
+
+// LoadSolution - Reads a LURD file and replays it on the current level (see ReplaySolution)
+func (c *Controller) LoadSolution(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return c.ReplaySolution(strings.TrimSpace(string(data)))
+}
+
+func (c *Controller) trySaveSolution() {
+	path := c.solutionPath()
+	if err := c.SaveSolution(path); err != nil {
+		c.printf("Save solution failed: %v\n", err)
+		return
+	}
+	c.printf("Solution saved to %s\n", path)
+}
+
+func (c *Controller) tryLoadSolution() {
+	path := c.solutionPath()
+	if err := c.LoadSolution(path); err != nil {
+		c.printf("Load solution failed: %v\n", err)
+		return
+	}
+	c.printf("Solution loaded from %s\n", path)
+}
+
+// SaveGame - Writes the current level and the moves played on it to the save file
+func (c *Controller) SaveGame() error {
+	if c.SavePath == "" || c.m.Board == nil {
+		return nil
+	}
+	return c.m.NewSaveGame().Write(c.SavePath)
+}
+
+// ResumeGame - Restarts the saved level and replays the saved moves (rebuilding the board and undo chain)
+func (c *Controller) ResumeGame(save *model.SaveGame) error {
+	if !c.m.LM.SetCurrentLevelNumber(save.Level) {
+		return fmt.Errorf("no level %d to resume", save.Level)
+	}
+	if err := c.ReplaySolution(save.Moves); err != nil {
+		c.loadLevel()
+		return err
+	}
+	c.m.Notice = fmt.Sprintf("Resumed level %d (-new to restart)", c.m.LM.GetCurrentLevelNumber())
+	c.printf("%s\n", c.m.Notice)
+	return nil
+}
+
+func (c *Controller) trySaveGame() {
+	if err := c.SaveGame(); err != nil {
+		c.printf("Save game failed: %v\n", err)
+	}
 }
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/TheInvader360/sokoban-go/direction"
 	"github.com/TheInvader360/sokoban-go/model"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,11 +23,11 @@ func TestStartNewGameAndRestartLevel(t *testing.T) {
 	assert.True(t, m.Board.Player.Y == 4)
 
 	// move player away from start position
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.False(t, m.Board.Player.Y == 4)
 
 	// restart level - level 1, player back at start position
-	c.HandleInput(pixelgl.KeyR)
+	c.HandleInput(Restart)
 	assert.Equal(t, "  ###     #.#     # #######$ $.##. $@#######$#     #.#     ###  ", m.LM.GetCurrentLevel().MapData)
 	assert.True(t, m.Board.Player.Y == 4)
 }
@@ -36,7 +39,7 @@ func TestPlayerMovementAndWallCollisions(t *testing.T) {
 		"# @#" +
 		"####"
 	b := model.NewBoard(mapData, 4, 4)
-	m := model.Model{Board: b}
+	m := model.Model{Board: b, Boards: model.NewTable(0)}
 	c := Controller{m: &m}
 
 	// start position
@@ -44,34 +47,34 @@ func TestPlayerMovementAndWallCollisions(t *testing.T) {
 	assert.Equal(t, 2, b.Player.Y)
 
 	// move up (first attempt succeeds, second attempt fails)
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, 2, b.Player.X)
 	assert.Equal(t, 1, b.Player.Y)
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, 2, b.Player.X)
 	assert.Equal(t, 1, b.Player.Y)
 
 	// move left (first attempt succeeds, second attempt fails)
-	c.HandleInput(pixelgl.KeyLeft)
+	c.HandleInput(MoveLeft)
 	assert.Equal(t, 1, b.Player.X)
 	assert.Equal(t, 1, b.Player.Y)
-	c.HandleInput(pixelgl.KeyLeft)
+	c.HandleInput(MoveLeft)
 	assert.Equal(t, 1, b.Player.X)
 	assert.Equal(t, 1, b.Player.Y)
 
 	// move down (first attempt succeeds, second attempt fails)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, 1, b.Player.X)
 	assert.Equal(t, 2, b.Player.Y)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, 1, b.Player.X)
 	assert.Equal(t, 2, b.Player.Y)
 
 	// move right (first attempt succeeds, second attempt fails)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveRight)
 	assert.Equal(t, 2, b.Player.X)
 	assert.Equal(t, 2, b.Player.Y)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveRight)
 	assert.Equal(t, 2, b.Player.X)
 	assert.Equal(t, 2, b.Player.Y)
 }
@@ -84,7 +87,7 @@ func TestPlayerAndBoxMovementAndCollisions(t *testing.T) {
 		"#     #" +
 		"#######"
 	b := model.NewBoard(mapData, 7, 5)
-	m := model.Model{Board: b}
+	m := model.Model{Board: b, Boards: model.NewTable(0)}
 	c := Controller{m: &m}
 
 	// start position
@@ -92,21 +95,21 @@ func TestPlayerAndBoxMovementAndCollisions(t *testing.T) {
 	assert.Equal(t, 2, b.Player.Y)
 
 	// try move left (fail: can't push box into wall)
-	c.HandleInput(pixelgl.KeyLeft)
+	c.HandleInput(MoveLeft)
 	assert.Equal(t, 2, b.Player.X)
 	assert.Equal(t, 2, b.Player.Y)
 
 	// try move right (success: box pushed to the right)
-	c.HandleInput(pixelgl.KeyRight)
-	assert.Equal(t, 3, b.Player.X)
-	assert.Equal(t, 2, b.Player.Y)
-	assert.False(t, b.Get(3, 2).HasBox)
-	assert.True(t, b.Get(4, 2).HasBox)
+	c.HandleInput(MoveRight)
+	assert.Equal(t, 3, m.Board.Player.X)
+	assert.Equal(t, 2, m.Board.Player.Y)
+	assert.False(t, m.Board.Get(3, 2).HasBox)
+	assert.True(t, m.Board.Get(4, 2).HasBox)
 
 	// try move right (fail: can't push box into other box)
-	c.HandleInput(pixelgl.KeyRight)
-	assert.Equal(t, 3, b.Player.X)
-	assert.Equal(t, 2, b.Player.Y)
+	c.HandleInput(MoveRight)
+	assert.Equal(t, 3, m.Board.Player.X)
+	assert.Equal(t, 2, m.Board.Player.Y)
 }
 
 func TestBoardCompletion(t *testing.T) {
@@ -117,7 +120,7 @@ func TestBoardCompletion(t *testing.T) {
 		"#@ #" +
 		"####"
 	b := model.NewBoard(mapData, 4, 5)
-	m := model.Model{Board: b}
+	m := model.Model{Board: b, Boards: model.NewTable(0)}
 	c := Controller{m: &m}
 
 	// start position
@@ -125,12 +128,12 @@ func TestBoardCompletion(t *testing.T) {
 	assert.Equal(t, 3, b.Player.Y)
 	assert.False(t, c.m.Board.IsComplete())
 
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
 	assert.False(t, c.m.Board.IsComplete())
 
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.True(t, c.m.Board.IsComplete())
 }
 
@@ -141,12 +144,12 @@ func TestStateLevelComplete(t *testing.T) {
 	m.LM.ProgressToNextLevel()
 
 	// input other than the space key has no effect
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StateLevelComplete, m.State)
 
 	// press the space key to start the next level
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 	assert.Equal(t, 2, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
 }
@@ -164,12 +167,12 @@ func TestStateGameComplete(t *testing.T) {
 	assert.Equal(t, model.StateGameComplete, m.State)
 
 	// input other than the space key has no effect
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, 3, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StateGameComplete, m.State)
 
 	// press the space key to start a new game
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
 }
@@ -181,618 +184,925 @@ func TestWalkthrough(t *testing.T) {
 
 	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 2, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 3, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 4, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 5, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 6, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 7, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 8, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 9, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, 10, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyLeft)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyDown)
-	c.HandleInput(pixelgl.KeyRight)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
-	c.HandleInput(pixelgl.KeyUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveLeft)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveDown)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
+	c.HandleInput(MoveUp)
 	assert.Equal(t, model.StateLevelComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 
 	assert.Equal(t, model.StateGameComplete, m.State)
-	c.HandleInput(pixelgl.KeySpace)
+	c.HandleInput(Confirm)
 	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
 	assert.Equal(t, model.StatePlaying, m.State)
 }
+
+func TestSaveAndReplaySolution(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(true)}
+	c := NewController(&m)
+	c.SolutionDir = t.TempDir()
+	c.StartNewGame()
+
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	assert.Equal(t, model.StateLevelComplete, m.State)
+
+	// press the s key to save the solution in LURD notation
+	c.HandleInput(SaveSolution)
+	path := filepath.Join(c.SolutionDir, "level_01.lurd")
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "rRR\n", string(data))
+
+	// replaying the saved solution completes the level again
+	assert.NoError(t, c.LoadSolution(path))
+	assert.Equal(t, model.StateLevelComplete, m.State)
+	assert.Equal(t, 3, m.Moves)
+
+	// replays stop at the first move that does not match the board
+	err = c.ReplaySolution("rr")
+	assert.EqualError(t, err, "move 2 (r): push expected to be true")
+	// the mismatching move is not played: the box stays put and only the first move can be undone
+	assert.Equal(t, 1, m.Moves)
+	assert.Equal(t, 2, m.Board.Player.X)
+	c.HandleInput(Undo)
+	assert.Nil(t, m.LastMove)
+	assert.Equal(t, 1, m.Board.Player.X)
+	err = c.ReplaySolution("l")
+	assert.EqualError(t, err, "move 1 (l): player blocked")
+	err = c.ReplaySolution("rRRr")
+	assert.EqualError(t, err, "move 4 (r): level already complete")
+	assert.NoError(t, c.ReplaySolution("r2(lr)"))
+	assert.Equal(t, model.StatePlaying, m.State)
+	assert.Equal(t, 5, m.Moves)
+}
+
+func TestSolutionPathPerPack(t *testing.T) {
+	dir := t.TempDir()
+	solutions := t.TempDir()
+	paths := []string{}
+	for _, pack := range []string{filepath.Join(dir, "a", "pack.xsb"), filepath.Join(dir, "b", "pack.xsb")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(pack), 0755))
+		assert.NoError(t, os.WriteFile(pack, []byte("#####\n#@$.#\n#####\n"), 0644))
+		lm, err := model.LoadLevelManager(pack)
+		assert.NoError(t, err)
+		c := NewController(&model.Model{LM: lm})
+		c.SolutionDir = solutions
+		c.StartNewGame()
+		c.HandleInput(MoveRight)
+		c.HandleInput(SaveSolution)
+		paths = append(paths, c.solutionPath())
+	}
+
+	// level 1 of two packs of the same name, and of the built-in levels, keep a solution each
+	builtIn := NewController(&model.Model{LM: model.NewLevelManager(false)})
+	builtIn.SolutionDir = solutions
+	builtIn.StartNewGame()
+	paths = append(paths, builtIn.solutionPath())
+	assert.Equal(t, filepath.Join(solutions, "level_01.lurd"), paths[2])
+	assert.NotEqual(t, paths[0], paths[1])
+	assert.Equal(t, "level_01.lurd", filepath.Base(paths[0]))
+	for _, path := range paths[:2] {
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "R\n", string(data))
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#     #" +
+		"#@$  .#" +
+		"#######"
+	b := model.NewBoard(mapData, 7, 4)
+	m := model.Model{Board: b, Boards: model.NewTable(0)}
+	c := Controller{m: &m}
+
+	// push the box twice, then undo both pushes
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	assert.True(t, m.Board.Get(4, 2).HasBox)
+	c.HandleInput(Undo)
+	c.HandleInput(Undo)
+	assert.Equal(t, 1, m.Board.Player.X)
+	assert.Equal(t, 0, m.Moves)
+	assert.True(t, m.Board.Get(2, 2).HasBox)
+	assert.False(t, m.Board.Get(4, 2).HasBox)
+	assert.Equal(t, 2, m.Board.Boxes[m.Board.Get(2, 2).Box].X)
+	assert.Equal(t, model.NewBoard(mapData, 7, 4).GetKey(), m.Board.GetKey())
+
+	// redo replays the undone pushes in order
+	c.HandleInput(Redo)
+	assert.Equal(t, 2, m.Board.Player.X)
+	assert.True(t, m.Board.Get(3, 2).HasBox)
+	assert.Equal(t, 3, m.Board.Boxes[m.Board.Get(3, 2).Box].X)
+	c.HandleInput(Redo)
+	assert.Equal(t, 3, m.Board.Player.X)
+	assert.True(t, m.Board.Get(4, 2).HasBox)
+	assert.Equal(t, 2, m.Moves)
+	assert.Nil(t, m.RedoMove)
+
+	// nothing left to redo
+	c.HandleInput(Redo)
+	assert.Equal(t, 3, m.Board.Player.X)
+
+	// playing the undone move by hand keeps the rest of the redo history
+	c.HandleInput(Undo)
+	c.HandleInput(Undo)
+	c.HandleInput(MoveRight)
+	assert.NotNil(t, m.RedoMove)
+	c.HandleInput(Redo)
+	assert.True(t, m.Board.Get(4, 2).HasBox)
+
+	// a diverging move drops the redo history
+	c.HandleInput(Undo)
+	c.HandleInput(MoveUp)
+	assert.Nil(t, m.RedoMove)
+	c.HandleInput(Redo)
+	assert.Equal(t, 2, m.Board.Player.X)
+	assert.Equal(t, 1, m.Board.Player.Y)
+	assert.True(t, m.Board.Get(3, 2).HasBox)
+}
+
+func TestSaveAndResumeGame(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(true)}
+	c := NewController(&m)
+	c.SavePath = filepath.Join(t.TempDir(), "save.json")
+	c.StartNewGame()
+	c.HandleInput(Confirm)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	c.HandleInput(MoveRight)
+	assert.Equal(t, model.StateLevelComplete, m.State)
+
+	// the game is saved on level completion
+	save, err := model.LoadSaveGame(c.SavePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, save.Level)
+	assert.Equal(t, "r2R", save.Moves)
+
+	// and on demand (e.g. on exit)
+	c.HandleInput(Confirm)
+	c.HandleInput(MoveUp)
+	assert.NoError(t, c.SaveGame())
+
+	// a new session resumes on the saved level with the board and undo chain rebuilt
+	m2 := model.Model{LM: model.NewLevelManager(true)}
+	c2 := NewController(&m2)
+	save, err = model.LoadSaveGame(c.SavePath)
+	assert.NoError(t, err)
+	assert.NoError(t, c2.ResumeGame(save))
+	assert.Equal(t, 2, m2.LM.GetCurrentLevelNumber())
+	assert.Equal(t, model.StatePlaying, m2.State)
+	assert.Equal(t, 1, m2.Moves)
+	assert.Equal(t, 2, m2.Board.Player.Y)
+	assert.True(t, m2.Board.Get(1, 1).HasBox)
+	assert.Equal(t, "Resumed level 2 (-new to restart)", m2.Notice)
+	c2.HandleInput(Undo)
+	assert.Equal(t, "", m2.Notice)
+	assert.Equal(t, 3, m2.Board.Player.Y)
+	assert.True(t, m2.Board.Get(1, 2).HasBox)
+
+	assert.Error(t, c2.ResumeGame(&model.SaveGame{Level: 9}))
+}
+
+func TestSolverHints(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false)}
+	c := NewController(&m)
+	c.StartNewGame()
+	assert.Equal(t, model.HintBackendBoard, m.Hints)
+
+	// switch to the solver, its hints lead to the fewest pushes
+	c.HandleInput(ToggleHintBackend)
+	assert.Equal(t, model.HintBackendSolver, m.Hints)
+	assert.Equal(t, "A*", m.Hints.String())
+	assert.True(t, m.Search.Running)
+	c.WaitForHints()
+	assert.False(t, m.Search.Running)
+	assert.Equal(t, 6, m.Search.Bound)
+	assert.Equal(t, 13, m.Board.GetBestPosition().BestLength)
+	assert.Equal(t, 13, m.BestMoves)
+	for i := 0; i < 100 && m.State == model.StatePlaying; i++ {
+		c.tryMovePlayer(m.Board.Get(m.Board.Player.X, m.Board.Player.Y).PathDir)
+		assert.Nil(t, c.search)
+	}
+	assert.Equal(t, model.StateLevelComplete, m.State)
+	assert.Equal(t, 13, m.Moves)
+	pushes := 0
+	for _, mv := range m.GetMoves() {
+		if mv.Push {
+			pushes++
+		}
+	}
+	assert.Equal(t, 6, pushes)
+
+	// leaving the plan solves again from the new position (here shorter in moves, the solver counts pushes), undo too
+	c.restartLevel()
+	c.HandleInput(MoveDown)
+	c.WaitForHints()
+	assert.Equal(t, 12, m.Moves+m.Board.GetBestPosition().BestLength)
+	c.HandleInput(Undo)
+	c.WaitForHints()
+	assert.Equal(t, 13, m.Board.GetBestPosition().BestLength)
+	assert.NotEqual(t, direction.None, m.Board.Get(m.Board.Player.X, m.Board.Player.Y).PathDir)
+
+	// and back to the board search
+	c.HandleInput(ToggleHintBackend)
+	assert.Equal(t, model.HintBackendBoard, m.Hints)
+	c.WaitForHints()
+	assert.Less(t, m.Board.GetBestPosition().BestLength, 999)
+	assert.Equal(t, m.Boards.Stats().Misses, m.Search.Explored)
+	assert.Equal(t, m.Boards.Stats(), m.Search.Table)
+}
+
+func TestSolverObjective(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false)}
+	c := NewController(&m)
+	c.StartNewGame()
+	c.HandleInput(ToggleHintBackend)
+	c.WaitForHints()
+	assert.Equal(t, model.ObjectivePushes, m.Objective)
+	assert.Equal(t, 13, m.Board.GetBestPosition().BestLength)
+
+	// fewest moves, then fewest pushes then moves: the plan is searched again
+	c.HandleInput(ToggleObjective)
+	assert.Equal(t, model.ObjectiveMoves, m.Objective)
+	c.WaitForHints()
+	assert.Equal(t, 10, m.Search.Bound)
+	assert.Equal(t, 10, m.Board.GetBestPosition().BestLength)
+	c.HandleInput(ToggleObjective)
+	assert.Equal(t, model.ObjectivePushesMoves, m.Objective)
+	c.WaitForHints()
+	assert.Equal(t, 10, m.Board.GetBestPosition().BestLength)
+
+	// back to pushes, the board search ignores the objective
+	c.HandleInput(ToggleObjective)
+	assert.Equal(t, model.ObjectivePushes, m.Objective)
+	c.HandleInput(ToggleHintBackend)
+	c.HandleInput(ToggleObjective)
+	assert.Equal(t, model.ObjectiveMoves, m.Objective)
+	assert.Equal(t, model.HintBackendBoard, m.Hints)
+}
+
+func TestBackgroundHints(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false)}
+	c := NewController(&m)
+	c.StartNewGame()
+
+	// the board search runs in the background, its hints show up once it is done
+	assert.True(t, m.Search.Running)
+	assert.Equal(t, 1000, m.Board.GetBestPosition().BestLength)
+	for m.Search.Running {
+		c.Update()
+	}
+	assert.False(t, m.Search.Expired)
+	assert.Equal(t, m.BestMoves, m.Board.GetBestPosition().BestLength)
+	assert.NotEqual(t, direction.None, m.Board.Get(m.Board.Player.X, m.Board.Player.Y).PathDir)
+
+	// moving cancels the running search and starts another one
+	c.HandleInput(MoveUp)
+	search := c.search
+	c.HandleInput(MoveDown)
+	assert.NotSame(t, search, c.search)
+	c.WaitForHints()
+	assert.Less(t, m.Board.GetBestPosition().BestLength, 999)
+
+	// out of time: no hint, and boards left half searched are dropped
+	c.HintTimeout = time.Nanosecond
+	c.HandleInput(Restart)
+	c.WaitForHints()
+	assert.True(t, m.Search.Expired)
+	assert.Equal(t, 1000, m.Board.GetBestPosition().BestLength)
+	assert.Equal(t, direction.None, m.Board.Get(m.Board.Player.X, m.Board.Player.Y).PathDir)
+	assert.Equal(t, 0, m.Boards.Len())
+}
+
+func TestZoom(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false)}
+	c := NewController(&m)
+	c.StartNewGame()
+
+	// zooming does not touch the board, whatever the game state, and stops MaxZoom steps away from the fitted size
+	c.HandleInput(ZoomIn)
+	c.HandleInput(ZoomIn)
+	assert.Equal(t, 2, m.Zoom)
+	assert.Equal(t, 0, m.Moves)
+	for i := 0; i < 2*model.MaxZoom; i++ {
+		c.HandleInput(ZoomOut)
+	}
+	assert.Equal(t, -model.MaxZoom, m.Zoom)
+	m.State = model.StateLevelComplete
+	c.HandleInput(ZoomIn)
+	assert.Equal(t, 1-model.MaxZoom, m.Zoom)
+	assert.Equal(t, model.StateLevelComplete, m.State)
+
+	// the next level starts fitted again
+	c.HandleInput(Confirm)
+	assert.Equal(t, 2, m.LM.GetCurrentLevelNumber())
+	assert.Equal(t, 0, m.Zoom)
+}
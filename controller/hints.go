@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/TheInvader360/sokoban-go/solver"
+)
+
+// solverMaxStates - Keeps the solver backend from running out of memory on levels too hard for it
+const solverMaxStates = 2000000
+
+// hintSearch - A hint search running in the background on a copy of the board, the game keeps going meanwhile
+type hintSearch struct {
+	backend   model.HintBackend
+	objective model.Objective
+	start     time.Time
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	mu       sync.Mutex
+	progress model.SearchProgress
+
+	// set once done
+	board *model.Board // the searched board (board backend)
+	plan  []direction.Direction
+	err   error
+}
+
+// report - Publishes the progress of the search (called from the search goroutine)
+func (s *hintSearch) report(explored, bound int) {
+	s.mu.Lock()
+	s.progress.Explored = explored
+	s.progress.Bound = bound
+	s.mu.Unlock()
+}
+
+// reportTable - Publishes the statistics of the board search table along with its progress (called from the search goroutine, which owns the table)
+func (s *hintSearch) reportTable(boards *model.Table) func(explored, bound int) {
+	return func(explored, bound int) {
+		s.mu.Lock()
+		s.progress.Table = boards.Stats()
+		s.mu.Unlock()
+		s.report(explored, bound)
+	}
+}
+
+// toggleHintBackend - Switches the hints and autoplay between the board search and the solver
+func (c *Controller) toggleHintBackend() {
+	if c.m.Hints == model.HintBackendBoard {
+		c.m.Hints = model.HintBackendSolver
+	} else {
+		c.m.Hints = model.HintBackendBoard
+	}
+	c.resetPlan()
+	c.updateHints()
+	c.printf("Hints from %v\n", c.m.Hints)
+}
+
+// toggleObjective - Cycles what the solver backend minimises: pushes, moves, then pushes and moves
+func (c *Controller) toggleObjective() {
+	c.m.Objective = (c.m.Objective + 1) % (model.ObjectivePushesMoves + 1)
+	if c.m.Hints == model.HintBackendSolver {
+		c.resetPlan()
+		c.updateHints()
+	}
+	c.printf("Solver minimises %v\n", c.m.Objective)
+}
+
+// updateHints - Clears the hints of the current board and starts a search for new ones (unless the solver plan still holds)
+func (c *Controller) updateHints() {
+	c.stopSearch()
+	c.m.Board.ResetHints()
+	c.m.Board.CheckEveryFreeSpace(c.m.Board.Player.X, c.m.Board.Player.Y)
+	if c.m.Hints == model.HintBackendSolver && c.planned {
+		solver.ApplyHint(c.m.Board, c.plan)
+		return
+	}
+	c.startSearch()
+}
+
+// startSearch - Searches a copy of the current board in the background with the selected backend, Update applies the hints once found
+func (c *Controller) startSearch() {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if c.HintTimeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), c.HintTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	s := &hintSearch{
+		backend:   c.m.Hints,
+		objective: c.m.Objective,
+		start:     time.Now(),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		progress:  model.SearchProgress{Running: true},
+	}
+	board := c.m.Board.Duplicate()
+	boards := c.m.Boards // owned by the search until it is done
+	c.search = s
+	c.m.Search = s.progress
+
+	go func() {
+		defer close(s.done)
+		defer cancel()
+		var plan []direction.Direction
+		var err error
+		if s.backend == model.HintBackendBoard {
+			board = board.GetBoard(boards)
+			err = board.CheckEveryBoxMoveFromPlayerContext(ctx, boards, s.reportTable(boards))
+		} else {
+			var r *solver.Result
+			r, err = solver.Solve(ctx, board, solver.Options{MaxStates: solverMaxStates, Progress: s.report, Workers: runtime.NumCPU(), Objective: s.objective})
+			if s.objective == model.ObjectiveMoves {
+				s.report(r.Explored, len(r.Moves))
+			} else {
+				s.report(r.Explored, r.Pushes)
+			}
+			plan = r.Moves
+		}
+		s.mu.Lock()
+		s.board, s.plan, s.err = board, plan, err
+		s.mu.Unlock()
+	}()
+}
+
+// stopSearch - Cancels the running search, if any, and waits for it to return (its hints are dropped)
+func (c *Controller) stopSearch() {
+	s := c.search
+	if s == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	c.search = nil
+	if s.backend == model.HintBackendBoard && s.err != nil {
+		// boards left half searched would give wrong hints later on
+		c.m.Boards.Reset()
+	}
+	c.m.Search = model.SearchProgress{}
+}
+
+// pollSearch - Publishes the progress of the running search, and applies its hints once it is done
+func (c *Controller) pollSearch() {
+	s := c.search
+	if s == nil {
+		return
+	}
+	select {
+	case <-s.done:
+		c.finishSearch()
+	default:
+		s.mu.Lock()
+		c.m.Search = s.progress
+		s.mu.Unlock()
+	}
+}
+
+// WaitForHints - Blocks until the running search (if any) is done and applies its hints
+func (c *Controller) WaitForHints() {
+	if c.search != nil {
+		<-c.search.done
+		c.finishSearch()
+	}
+}
+
+// finishSearch - Applies the hints of the search that is done, or reports that none is available
+func (c *Controller) finishSearch() {
+	s := c.search
+	c.search = nil
+	c.m.Search = s.progress
+	c.m.Search.Running = false
+	c.m.SolveDuration = time.Since(s.start)
+
+	switch {
+	case s.backend == model.HintBackendSolver && (s.err == nil || errors.Is(s.err, solver.ErrNoSolution)):
+		c.plan = s.plan
+		c.planned = true
+		solver.ApplyHint(c.m.Board, c.plan)
+	case s.backend == model.HintBackendBoard && s.err == nil:
+		c.m.Board.CopyHints(s.board)
+	default:
+		// out of time (or states): try again after the next move
+		c.printf("No hint available: %v\n", s.err)
+		if s.backend == model.HintBackendBoard {
+			c.m.Boards.Reset()
+		}
+		c.m.Search.Expired = true
+		return
+	}
+	if c.m.LastMove == nil {
+		c.m.BestMoves = c.m.Board.GetBestPosition().BestLength
+	}
+}
+
+// followPlan - Keeps the solver plan while the moves played match it, drops it as soon as they diverge
+func (c *Controller) followPlan(dir direction.Direction) {
+	if len(c.plan) > 0 && c.plan[0] == dir {
+		c.plan = c.plan[1:]
+	} else {
+		c.resetPlan()
+	}
+}
+
+// resetPlan - Forgets the solver plan, the next hints update solves again
+func (c *Controller) resetPlan() {
+	c.plan = nil
+	c.planned = false
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheInvader360/sokoban-go/bindings"
+
+	pixelgl "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+)
+
+// buttonNames - Returns every pixel key by lower case name (see pixelgl.Button.String)
+func buttonNames() map[string]pixelgl.Button {
+	names := map[string]pixelgl.Button{}
+	for b := pixelgl.KeySpace; b <= pixelgl.KeyMenu; b++ {
+		names[strings.ToLower(b.String())] = b
+	}
+	return names
+}
+
+// checkKeys - Returns an error if a key name of the keymap is not a pixel key
+func checkKeys(keymap *bindings.Keymap) error {
+	names := buttonNames()
+	for action, keys := range keymap.Keys {
+		for _, key := range keys {
+			if _, ok := names[strings.ToLower(key)]; !ok {
+				return fmt.Errorf("%v: unknown key %q", action, key)
+			}
+		}
+	}
+	return nil
+}
+
+// keyboard - Tells bindings.Input which keys are down from the physical key state, one character key names going through the keyboard layout
+// learnt from the text typed (see bindings.Layout)
+type keyboard struct {
+	win    *opengl.Window
+	names  map[string]pixelgl.Button
+	layout *bindings.Layout
+}
+
+func newKeyboard(win *opengl.Window) *keyboard {
+	return &keyboard{win: win, names: buttonNames(), layout: bindings.NewLayout()}
+}
+
+// update - Learns the layout from the text typed this frame and the printable keys that went down with it (called once per frame, before pressed)
+func (k *keyboard) update() {
+	keys := []string{}
+	for b := pixelgl.KeySpace; b <= pixelgl.KeyWorld2; b++ {
+		if k.win.JustPressed(b) {
+			keys = append(keys, b.String())
+		}
+	}
+	k.layout.Learn(k.win.Typed(), keys)
+}
+
+// pressed - Returns whether a key (by name) is down, or went down and up again since the last frame
+func (k *keyboard) pressed(key string) bool {
+	name, ok := k.layout.Key(key)
+	if !ok {
+		return false
+	}
+	b := k.names[name]
+	return k.win.Pressed(b) || k.win.JustPressed(b)
+}
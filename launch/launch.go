@@ -0,0 +1,107 @@
+// Package launch starts a game the same way in every frontend: the shared flags, the levels, the save game and the key bindings
+package launch
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/bindings"
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// Flags - The command line of the frontends
+type Flags struct {
+	Levels      string        // level pack file or directory, "" for the built-in levels (or the saved game's)
+	Save        string        // save game file, "" for the default one (see model.DefaultSavePath)
+	New         bool          // start a new game instead of resuming the saved one
+	HintTimeout time.Duration // time budget of a hint search
+	HintMemory  int           // memory limit in MB of the boards kept by the hint search
+	Keys        string        // key bindings file, "" for the default one if any (see bindings.DefaultPath)
+}
+
+// RegisterFlags - Defines the flags of the frontends on fs
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := Flags{}
+	fs.StringVar(&f.Levels, "levels", "", "level pack file (.xsb/.sok) or directory of packs to play instead of the built-in levels")
+	fs.StringVar(&f.Save, "save", "", "save game file (defaults to sokoban-go/save.json under the user config directory)")
+	fs.BoolVar(&f.New, "new", false, "start a new game instead of resuming the saved one")
+	fs.DurationVar(&f.HintTimeout, "hint-timeout", 10*time.Second, "time budget of a hint search, no hint is shown past it (0 for no limit)")
+	fs.IntVar(&f.HintMemory, "hint-memory", model.DefaultTableBytes>>20, "memory limit in MB of the boards kept by the hint search (0 for no limit)")
+	fs.StringVar(&f.Keys, "keys", "", "key bindings file (defaults to sokoban-go/keys.json under the user config directory, if any)")
+	return &f
+}
+
+// Start - Creates the model and controller of the flags and starts the game: the saved one, unless New is set or Levels names another pack,
+// else a new one. A saved game that cannot be resumed (e.g. its pack was moved or deleted) starts a new one too. The game messages go to out
+func Start(f *Flags, out io.Writer) (*model.Model, *controller.Controller, error) {
+	savePath := f.Save
+	if savePath == "" {
+		if path, err := model.DefaultSavePath(); err == nil {
+			savePath = path
+		}
+	}
+	levelsPath := f.Levels
+	savedLevels := levelsPath == ""
+	save, err := model.LoadSaveGame(savePath)
+	if err != nil || f.New || !save.IsOf(levelsPath) && !savedLevels {
+		save = nil
+	} else if savedLevels {
+		levelsPath = save.Levels
+	}
+
+	m := model.NewModel()
+	m.Boards.MaxBytes = f.HintMemory << 20
+	if levelsPath != "" {
+		lm, err := model.LoadLevelManager(levelsPath)
+		switch {
+		case err == nil:
+			m.LM = lm
+		case save != nil && savedLevels:
+			// the saved pack was moved or deleted, start a new game of the built-in levels
+			fmt.Fprintf(out, "Resume failed: %v\n", err)
+			m.Notice = "Saved levels not found, new game started"
+			save = nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	c := controller.NewController(m)
+	c.Out = out
+	c.SavePath = savePath
+	c.HintTimeout = f.HintTimeout
+	if save == nil {
+		c.StartNewGame()
+	} else if err := c.ResumeGame(save); err != nil {
+		fmt.Fprintf(out, "Resume failed: %v\n", err)
+		c.StartNewGame()
+	}
+	return m, c, nil
+}
+
+// LoadKeymap - Loads the keymap file at path, or the one under the user config directory if path is "" (the built-in keys if there is none).
+// The keys bound to several actions are reported to warn
+func LoadKeymap(path string, warn io.Writer) (*bindings.Keymap, error) {
+	if path == "" {
+		if p, err := bindings.DefaultPath(); err == nil {
+			if _, err := os.Stat(p); err == nil {
+				path = p
+			}
+		}
+	}
+	if path == "" {
+		return bindings.Default(), nil
+	}
+	keymap, err := bindings.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range keymap.Conflicts() {
+		fmt.Fprintf(warn, "Key bindings: %v\n", c)
+	}
+	return keymap, nil
+}
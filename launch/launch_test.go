@@ -0,0 +1,91 @@
+package launch
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const pack = `#######
+#@ $ .#
+#######
+
+######
+#@ $.#
+######
+`
+
+// parseFlags - Returns the flags of the command line, the save game in dir
+func parseFlags(t *testing.T, dir string, args ...string) *Flags {
+	fs := flag.NewFlagSet("sokoban", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	assert.NoError(t, fs.Parse(append([]string{"-save", filepath.Join(dir, "save.json")}, args...)))
+	return f
+}
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+	packPath := filepath.Join(dir, "pack.xsb")
+	assert.NoError(t, os.WriteFile(packPath, []byte(pack), 0644))
+	out := &bytes.Buffer{}
+
+	// a new game of the pack, saved on level 2 after a move
+	m, c, err := Start(parseFlags(t, dir, "-levels", packPath, "-hint-memory", "1"), out)
+	assert.NoError(t, err)
+	assert.Equal(t, 1<<20, m.Boards.MaxBytes)
+	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
+	c.HandleInput(controller.MoveRight)
+	c.HandleInput(controller.MoveRight)
+	c.HandleInput(controller.MoveRight)
+	c.HandleInput(controller.Confirm)
+	c.HandleInput(controller.MoveRight)
+	c.WaitForHints()
+	assert.NoError(t, c.SaveGame())
+	assert.Contains(t, out.String(), "Start level 2\n")
+
+	// resumed without -levels
+	m, c, err = Start(parseFlags(t, dir), out)
+	assert.NoError(t, err)
+	c.WaitForHints()
+	assert.Equal(t, 2, m.LM.GetCurrentLevelNumber())
+	assert.Equal(t, 1, m.Moves)
+	assert.Equal(t, "Resumed level 2 (-new to restart)", m.Notice)
+
+	// unless -new is set or -levels names another pack
+	m, c, err = Start(parseFlags(t, dir, "-new", "-levels", packPath), out)
+	assert.NoError(t, err)
+	c.WaitForHints()
+	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
+	assert.Equal(t, 2, m.LM.GetFinalLevelNumber())
+	_, _, err = Start(parseFlags(t, dir, "-levels", filepath.Join(dir, "missing.xsb")), out)
+	assert.Error(t, err)
+
+	// a saved pack that is gone starts a new game of the built-in levels
+	assert.NoError(t, os.Remove(packPath))
+	m, c, err = Start(parseFlags(t, dir), out)
+	assert.NoError(t, err)
+	c.WaitForHints()
+	assert.Equal(t, 1, m.LM.GetCurrentLevelNumber())
+	assert.Equal(t, model.NewLevelManager(false).GetFinalLevelNumber(), m.LM.GetFinalLevelNumber())
+	assert.Equal(t, "Saved levels not found, new game started", m.Notice)
+	assert.Contains(t, out.String(), "Resume failed: ")
+}
+
+func TestLoadKeymap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"keys": {"Undo": ["U", "R"]}}`), 0644))
+	warn := &bytes.Buffer{}
+	keymap, err := LoadKeymap(path, warn)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"U", "R"}, keymap.Keys[controller.Undo])
+	assert.Equal(t, "Key bindings: key \"R\" is bound to Undo and Restart\n", warn.String())
+
+	_, err = LoadKeymap(filepath.Join(t.TempDir(), "missing.json"), warn)
+	assert.Error(t, err)
+}
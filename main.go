@@ -1,11 +1,16 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
 	"time"
 
-	"github.com/TheInvader360/sokoban-go/controller"
-	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/TheInvader360/sokoban-go/bindings"
+	"github.com/TheInvader360/sokoban-go/cli"
+	"github.com/TheInvader360/sokoban-go/launch"
 	"github.com/TheInvader360/sokoban-go/view"
+	"github.com/TheInvader360/sokoban-go/view/glrender"
 
 	pixelgl "github.com/gopxl/pixel/v2"
 	"github.com/gopxl/pixel/v2/backends/opengl"
@@ -17,6 +22,8 @@ const (
 	scaleFactor = 3
 )
 
+var flags = launch.RegisterFlags(flag.CommandLine)
+
 func run() {
 	cfg := opengl.WindowConfig{
 		Title:  "Sokoban",
@@ -28,11 +35,34 @@ func run() {
 		panic(err)
 	}
 
-	m := model.NewModel()
-	v := view.NewView(m, win, scaleFactor)
-	c := controller.NewController(m)
-	lastKey := pixelgl.UnknownButton
-	c.StartNewGame()
+	keymap, err := launch.LoadKeymap(flags.Keys, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+	if err := checkKeys(keymap); err != nil {
+		panic(err)
+	}
+	keys := newKeyboard(win)
+	input := bindings.NewInput(keymap)
+
+	m, c, err := launch.Start(flags, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	sheet, err := view.LoadSpritesheet("assets/spritesheet.png")
+	if err != nil {
+		panic(err)
+	}
+	ttf, err := view.LoadFont("assets/HackJack.ttf")
+	if err != nil {
+		panic(err)
+	}
+	v := view.NewView(m, glrender.New(win, scaleFactor, sheet, ttf))
+	defer func() {
+		if err := c.SaveGame(); err != nil {
+			fmt.Fprintf(os.Stderr, "Save game failed: %v\n", err)
+		}
+	}()
 
 	// Main game loop
 	for !win.Closed() {
@@ -40,57 +70,13 @@ func run() {
 			return
 		}
 
-		// Fire an event once per key press (no repeats if the key is held down)
-		// Note: JustPressed() is a cleaner way to achieve this, but Pressed() more closely matches the Jack OS API
-		if win.Pressed(pixelgl.KeyUp) {
-			if lastKey != pixelgl.KeyUp {
-				c.HandleInput(pixelgl.KeyUp)
-			}
-			lastKey = pixelgl.KeyUp
-		} else if win.Pressed(pixelgl.KeyDown) {
-			if lastKey != pixelgl.KeyDown {
-				c.HandleInput(pixelgl.KeyDown)
-			}
-			lastKey = pixelgl.KeyDown
-		} else if win.Pressed(pixelgl.KeyLeft) {
-			if lastKey != pixelgl.KeyLeft {
-				c.HandleInput(pixelgl.KeyLeft)
-			}
-			lastKey = pixelgl.KeyLeft
-		} else if win.Pressed(pixelgl.KeyRight) {
-			if lastKey != pixelgl.KeyRight {
-				c.HandleInput(pixelgl.KeyRight)
-			}
-			lastKey = pixelgl.KeyRight
-		} else if win.Typed() == "z" {
-			if lastKey != pixelgl.KeyZ {
-				c.HandleInput(pixelgl.KeyZ)
-			}
-			lastKey = pixelgl.KeyZ
-		} else if win.Typed() == "f" {
-			if lastKey != pixelgl.KeyF {
-				c.HandleInput(pixelgl.KeyF)
-			}
-			lastKey = pixelgl.KeyF
-		} else if win.Typed() == "r" {
-			if lastKey != pixelgl.KeyR {
-				c.HandleInput(pixelgl.KeyR)
-			}
-			lastKey = pixelgl.KeyR
-		} else if win.Typed() == "a" {
-			if lastKey != pixelgl.KeyA {
-				c.HandleInput(pixelgl.KeyA)
-			}
-			lastKey = pixelgl.KeyR
-		} else if win.Pressed(pixelgl.KeySpace) {
-			if lastKey != pixelgl.KeySpace {
-				c.HandleInput(pixelgl.KeySpace)
-			}
-			lastKey = pixelgl.KeySpace
-		} else {
-			lastKey = pixelgl.UnknownButton
+		// Fire an event once per key press, held movement keys repeat if the keymap says so
+		keys.update()
+		if action, ok := input.Update(time.Now(), keys.pressed); ok {
+			c.HandleInput(action)
 		}
 
+		c.Update()
 		m.Update()
 
 		v.Draw(c.ShowFreeSpace)
@@ -100,5 +86,11 @@ func run() {
 }
 
 func main() {
+	// Subcommands (e.g. "solve") run headless, without OpenGL
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
+	flag.Parse()
 	opengl.Run(run)
 }
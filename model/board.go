@@ -20,7 +20,6 @@ type Cell struct {
 	IsPath bool
 	PathDir direction.Direction
 	Box int
-	Dist map[Position]int
 }
 
 type Position struct {
@@ -50,22 +49,6 @@ func NewBox(x,y int) *Box {
 	return b
 }
 
-func getCharFromNum(i int32) string {
-	return string(65+i)
-}
- 
-func getCellString(c Cell) string {
-	var i int32
-	if c.TypeOf == CellTypeNone { i = 0 
-	} else if c.TypeOf == CellTypeGoal { i = 1 
-	} else if c.TypeOf == CellTypeWall { i = 2 }
-
-	if c.HasBox { i = i + 3 }
-	if c.IsFree { i = i + 6 }
-
-	return getCharFromNum(i)
-}
-
 type BestPosition struct {
 	BestDir direction.Direction
 	BestX, BestY int
@@ -79,6 +62,16 @@ type Board struct {
 	Player        *Player
 
 	BestPositions map[Position]*BestPosition
+	BoxHash       uint64 // Zobrist hash of the box positions, kept up to date by MoveBox (see GetKey)
+	Dists         map[Position][]int // walking distances from a position, by cell index (see GetDist)
+	DeadCells     []bool // cells (by index) a box shall never be pushed to, computed once per level and shared by its boards
+
+	queue []int
+	search *search // the search running on this board, if any
+	corral []bool // boxes (by index) the search is restricted to by a PI-corral, nil for every box (see _FindPICorral and Table.PICorrals)
+	searching int // the search is inside this board, the table shall not evict it
+	tableBytes int // memory the table accounts for this board
+	tableRegion int // player region when added to the table (see Table._Get)
 }
 
 func (b *Board) GetBestPosition() *BestPosition {
@@ -99,6 +92,7 @@ func NewBoard(mapData string, boardWidth, boardHeight int) *Board {
 	b.Boxes = make([]Box, 0)
 
 	b.BestPositions = make(map[Position]*BestPosition)
+	b.Dists = make(map[Position][]int)
 
 	for y := 0; y < b.Height; y++ {
 		for x := 0; x < b.Width; x++ {
@@ -131,9 +125,13 @@ func NewBoard(mapData string, boardWidth, boardHeight int) *Board {
 	}
 
 	b._ResetCanBoxMove()
+	b._HashBoxes()
+	b._FindDeadCells()
 
 	// assume max length
-	b.BestPositions[Position{X:b.Player.X,Y:b.Player.Y}] = &BestPosition{BestLength:1000,BestX:-1,BestY:-1}
+	if b.Player != nil {
+		b.BestPositions[Position{X:b.Player.X,Y:b.Player.Y}] = &BestPosition{BestLength:1000,BestX:-1,BestY:-1}
+	}
 
 	return &b
 }
@@ -155,14 +153,6 @@ func (b *Board) Print() {
 	}
 }
 
-func (b *Board) GetString() string {
-	var str string
-	for _, cell := range b.Cells {
-		str = str + getCellString(cell)
-	}
-	return str
-}
-
 func (b *Board) Duplicate() *Board {
 	d := &Board{}
 	d.Width = b.Width
@@ -171,6 +161,7 @@ func (b *Board) Duplicate() *Board {
 	d.Cells = make([]Cell, b.Width*b.Height)
 	d.Boxes = make([]Box, len(b.Boxes))
 	d.BestPositions = make(map[Position]*BestPosition)
+	d.Dists = make(map[Position][]int)
 
 	for i, cell := range b.Cells {
 		d.Cells[i].TypeOf = cell.TypeOf
@@ -186,7 +177,10 @@ func (b *Board) Duplicate() *Board {
 		d.Boxes[i].XYChecked = make(map[Position]bool)
 	}
 
+	d.BoxHash = b.BoxHash
+	d.DeadCells = b.DeadCells
 	d.Player = NewPlayer(b.Player.X,b.Player.Y)
+	d.search = b.search
 
 	return d
 }
@@ -371,7 +365,7 @@ func (b *Board) _CheckEveryBoxIsStuck() bool {
 
 func (b *Board) _CheckEveryBoxIsTrap() bool {
 	traped := false
-	traped = b._CheckEveryBoxIsStuck() || b._CheckEveryBoxIsTrapByWall() // || b._CheckEveryBoxIsDead() // Note : Stuck includes Dead ones
+	traped = b._CheckEveryBoxIsOnDeadCell() || b._CheckEveryBoxIsFrozen() || b._CheckEveryBoxIsStuck() || b._CheckEveryBoxIsTrapByWall() // || b._CheckEveryBoxIsDead() // Note : Stuck includes Dead ones
 	return traped
 }
 
@@ -385,7 +379,7 @@ func getMoveDirection(dir direction.Direction) (int,int) {
 	return 0,0
 }
 
-func (b *Board) _CheckOneBoxMoveInDir(x,y, fromx,fromy int, box *Box, from, to Position, dir direction.Direction, boards map[string]*Board) {
+func (b *Board) _CheckOneBoxMoveInDir(x,y, fromx,fromy int, box *Box, from, to Position, dir direction.Direction, boards *Table) {
 
 	dx, dy := getMoveDirection(dir)
 
@@ -396,12 +390,14 @@ func (b *Board) _CheckOneBoxMoveInDir(x,y, fromx,fromy int, box *Box, from, to P
 		box.IsChecked[dir] = true
 		if (cup.IsFree && cdown.TypeOf != CellTypeWall && !cdown.HasBox) {
 			box.CanMove[dir] = true
+			// never push onto a dead cell, nor outside a PI-corral to open, no need to search further
+			if b.IsDeadCell(x-dx,y-dy) || (b.corral != nil && !b.corral[b.Get(x,y).Box]) { return }
 			newBoard:= b.MoveBoxAndCheck(x,y,dir,boards)
-			if newBoard.GetGoodBoxMoveCount() > 0 || newBoard.BestPositions[to].BestLength == 0 {
+			if newBoard.GetGoodBoxMoveCount() > 0 || newBoard.BestPositions[to].BestLength < 999 {
 				box.ShallNotMove[dir] = false
 				b.CheckEveryDist(fromx,fromy)
-				if newBoard.BestPositions[to].BestLength+1+cup.Dist[from]<b.BestPositions[from].BestLength {
-					b.BestPositions[from].BestLength = newBoard.BestPositions[to].BestLength+1+cup.Dist[from]
+				if newBoard.BestPositions[to].BestLength+1+b.GetDist(from,x+dx,y+dy)<b.BestPositions[from].BestLength {
+					b.BestPositions[from].BestLength = newBoard.BestPositions[to].BestLength+1+b.GetDist(from,x+dx,y+dy)
 					b.BestPositions[from].BestX = x
 					b.BestPositions[from].BestY = y
 					b.BestPositions[from].BestDir = dir
@@ -410,10 +406,10 @@ func (b *Board) _CheckOneBoxMoveInDir(x,y, fromx,fromy int, box *Box, from, to P
 		}
 	} else if !box.XYChecked[from] && box.CanMove[dir] && !box.ShallNotMove[dir] {
 		box.XYChecked[from] = true
-		newBoard:= b.GetOldMoveBox(x,y,dir,boards)
+		newBoard:= b.MoveBoxAndCheck(x,y,dir,boards)
 		b.CheckEveryDist(fromx,fromy)
-		if newBoard!= nil && newBoard.BestPositions[to].BestLength+1+cup.Dist[from]<b.BestPositions[from].BestLength {
-			b.BestPositions[from].BestLength = newBoard.BestPositions[to].BestLength+1+cup.Dist[from]
+		if newBoard.BestPositions[to].BestLength+1+b.GetDist(from,x+dx,y+dy)<b.BestPositions[from].BestLength {
+			b.BestPositions[from].BestLength = newBoard.BestPositions[to].BestLength+1+b.GetDist(from,x+dx,y+dy)
 			b.BestPositions[from].BestX = x
 			b.BestPositions[from].BestY = y
 			b.BestPositions[from].BestDir = dir
@@ -423,7 +419,7 @@ func (b *Board) _CheckOneBoxMoveInDir(x,y, fromx,fromy int, box *Box, from, to P
 }
 
 // Assume x,y got a box
-func (b *Board) _CheckOneBoxMove(x,y int,boards map[string]*Board) {
+func (b *Board) _CheckOneBoxMove(x,y int,boards *Table) {
 	c := b.Get(x,y)
 	box := &(b.Boxes[c.Box])
 	
@@ -439,11 +435,22 @@ func (b *Board) _CheckOneBoxMove(x,y int,boards map[string]*Board) {
 	box.XYChecked[from] = true
 }
 
-func (b *Board) _CheckEveryBoxMove(boards map[string]*Board) {
+func (b *Board) _CheckEveryBoxMove(boards *Table) {
 	PlayerPos := Position{X:b.Player.X,Y:b.Player.Y}
 	if b.BestPositions[PlayerPos] == nil {
 		b.BestPositions[PlayerPos] = &BestPosition{BestLength:1000,BestX:-1,BestY:-1}
 	}
+
+	// a PI-corral that can never be opened is a deadlock, the others restrict the search to the pushes opening them (unless the table says otherwise)
+	corral, closed := b._FindPICorral()
+	if closed {
+		b.BestPositions[PlayerPos].BestLength = 999
+		return
+	}
+	b.corral = nil
+	if boards.PICorrals {
+		b.corral = corral
+	}
 	
 	for i :=0;i<len(b.Boxes);i++ {
 		// it loses player pos because of oldmovebox uses
@@ -455,88 +462,107 @@ func (b *Board) _CheckEveryBoxMove(boards map[string]*Board) {
 	}
 }
 
-func (b *Board) ResetFreeSpace(from Position) {
-	for i :=0;i<len(b.Cells);i++ {
-		b.Cells[i].IsFree = false
-		if b.Cells[i].Dist == nil {
-			b.Cells[i].Dist = make(map[Position]int)
-		}
-		b.Cells[i].Dist[from] = 999
-	}
+// noDist - Distance of the cells that cannot be reached
+const noDist = 999
+
+// GetDist - Returns the walking distance from position from to x,y (999 if unreachable or not computed yet)
+func (b *Board) GetDist(from Position, x, y int) int {
+	dist, ok := b.Dists[from]
+	if !ok { return noDist }
+	return dist[(y*b.Width)+x]
 }
 
-// Private Checkup every Free Space from position
-func (b *Board) _CheckEveryFreeSpace(from Position, x, y, dist int) {
-	c := b.Get(x,y)
-	if (c.TypeOf == CellTypeWall || c.HasBox || dist >= c.Dist[from]) {
-		return
+// newDist - Returns a distance buffer (one entry per cell index) for position from, every cell set unreachable
+func (b *Board) newDist(from Position) []int {
+	dist := make([]int, len(b.Cells))
+	for i := range dist {
+		dist[i] = noDist
 	}
-	c.Dist[from] = dist
-	c.IsFree = true
-	b._CheckEveryFreeSpace(from,x-1,y,dist+1)
-	b._CheckEveryFreeSpace(from,x+1,y,dist+1)
-	b._CheckEveryFreeSpace(from,x,y-1,dist+1)
-	b._CheckEveryFreeSpace(from,x,y+1,dist+1)
+	b.Dists[from] = dist
+	return dist
 }
 
-func (b *Board) CheckEveryFreeSpace(x, y int) {
-	from := Position{X:x,Y:y}
-	c := b.Get(x,y)
-	_, ok := c.Dist[from]
-	if ok { return } 
-	b.ResetFreeSpace(from)
-	b._CheckEveryFreeSpace(from,x,y,0)
+// _BreadthFirst - Iterative breadth-first search from x,y through the cells accepted by canWalk, filling dist (by cell index) and calling visit on every reached cell
+func (b *Board) _BreadthFirst(x, y int, dist []int, canWalk func(c *Cell) bool, visit func(c *Cell)) {
+	start := (y*b.Width)+x
+	if !canWalk(&b.Cells[start]) { return }
+
+	queue := b.queue[:0]
+	dist[start] = 0
+	queue = append(queue, start)
+	for head := 0; head < len(queue); head++ {
+		i := queue[head]
+		visit(&b.Cells[i])
+		neighbours := [4]int{-1, -1, -1, -1}
+		if i%b.Width > 0 { neighbours[0] = i-1 }
+		if i%b.Width < b.Width-1 { neighbours[1] = i+1 }
+		if i >= b.Width { neighbours[2] = i-b.Width }
+		if i < len(b.Cells)-b.Width { neighbours[3] = i+b.Width }
+		for _, n := range neighbours {
+			if n >= 0 && dist[n] == noDist && canWalk(&b.Cells[n]) {
+				dist[n] = dist[i]+1
+				queue = append(queue, n)
+			}
+		}
+	}
+	b.queue = queue
 }
 
-func (b *Board) ResetDist(from Position) {
+func (b *Board) ResetFreeSpace(from Position) []int {
 	for i :=0;i<len(b.Cells);i++ {
-		b.Cells[i].Dist[from] = 999
+		b.Cells[i].IsFree = false
 	}
+	return b.newDist(from)
 }
 
-// Private Checkup every Free Space from position
-func (b *Board) _CheckEveryDist(from Position,x, y, dist int) {
-	c := b.Get(x,y)
-	if (!c.IsFree || dist >= c.Dist[from]) {
-		return
-	}
-	c.Dist[from] = dist
-	b._CheckEveryDist(from,x-1,y,dist+1)
-	b._CheckEveryDist(from,x+1,y,dist+1)
-	b._CheckEveryDist(from,x,y-1,dist+1)
-	b._CheckEveryDist(from,x,y+1,dist+1)
+// Checkup every Free Space (cells without wall nor box) reachable from position, with their distance
+func (b *Board) CheckEveryFreeSpace(x, y int) {
+	from := Position{X:x,Y:y}
+	if _, ok := b.Dists[from]; ok { return }
+	dist := b.ResetFreeSpace(from)
+	b._BreadthFirst(x, y, dist,
+		func(c *Cell) bool { return c.TypeOf != CellTypeWall && !c.HasBox },
+		func(c *Cell) { c.IsFree = true })
+}
+
+func (b *Board) ResetDist(from Position) []int {
+	return b.newDist(from)
 }
 
+// Checkup the distance of every Free Space from position
 func (b *Board) CheckEveryDist(x, y int) {
 	from := Position{X:x,Y:y}
-	c := b.Get(x,y)
-	_, ok := c.Dist[from]
-	if ok { return }
-
-	b.ResetDist(from)
-	b._CheckEveryDist(from,x,y,0)
+	if _, ok := b.Dists[from]; ok { return }
+	dist := b.ResetDist(from)
+	b._BreadthFirst(x, y, dist,
+		func(c *Cell) bool { return c.IsFree },
+		func(c *Cell) {})
 }
 
-func (b *Board) _CheckEveryBoxMoveFromPlayer(boards map[string]*Board) {
+func (b *Board) _CheckEveryBoxMoveFromPlayer(boards *Table) {
 	Pos := Position{X:b.Player.X,Y:b.Player.Y}
 
 	if b.BestPositions[Pos] == nil {
 		b.BestPositions[Pos] = &BestPosition{BestLength:1000,BestX:-1,BestY:-1}
 	}
 
-	if b.BestPositions[Pos].BestLength==999 || b.BestPositions[Pos].BestLength ==0 { return }
+	// already searched from here (or known from the table)
+	if b.BestPositions[Pos].BestLength < 1000 { return }
+	if b.search.stopped() { return }
 
 	if b._CheckEveryBoxIsTrap() {
 		b.BestPositions[Pos].BestLength = 999
 	} else if b.IsComplete() {
 		b.BestPositions[Pos].BestLength = 0
 	} else {
+		b.searching++
 		b._CheckEveryBoxMove(boards)
+		b.searching--
 	}
 }
 
 // Checkup every Free Space from player position
-func (b *Board) CheckEveryBoxMoveFromPlayer(boards map[string]*Board) {
+func (b *Board) CheckEveryBoxMoveFromPlayer(boards *Table) {
 	X := b.Player.X
 	Y := b.Player.Y
 	Pos := Position{X:X,Y:Y}
@@ -558,31 +584,42 @@ func (b *Board) MoveBox(x,y int, dir direction.Direction) {
 	Pos := Position{X:b.Player.X,Y:b.Player.Y}
 	b.BestPositions[Pos] = &BestPosition{BestLength:1000,BestX:-1,BestY:-1}
 
-	lastCell := b.Get(x,y)
 	b.Player.X = x
 	b.Player.Y = y
-	var newCell *Cell
-	box := &b.Boxes[lastCell.Box]
 	dx,dy := getMoveDirection(dir)
-	newCell = b.Get(x-dx,y-dy)
-	box.X = x-dx
-	box.Y = y-dy
+	b.PlaceBox(x,y,x-dx,y-dy)
+}
+
+// IsPush - Returns true if the player moving one step in dir would push a box (whether the box can move or not)
+func (b *Board) IsPush(dir direction.Direction) bool {
+	dx,dy := getMoveDirection(dir)
+	x,y := b.Player.X-dx,b.Player.Y-dy
+	return b._IsFloor(x,y) && b.Get(x,y).HasBox
+}
+
+// PlaceBox - Moves the box at x,y to toX,toY (keeping the cells, box index and box hash in step)
+func (b *Board) PlaceBox(x,y, toX,toY int) {
+	lastCell := b.Get(x,y)
+	newCell := b.Get(toX,toY)
+	box := &b.Boxes[lastCell.Box]
+	box.X = toX
+	box.Y = toY
 
 	lastCell.HasBox = false
 	newCell.HasBox = true
 	newCell.Box = lastCell.Box
+
+	b.BoxHash ^= zobrist((y*b.Width)+x, zobristBox) ^ zobrist((toY*b.Width)+toX, zobristBox)
 }
 
-func (b *Board) GetBoard(boards map[string]*Board) *Board {
+// GetBoard - Returns the board to search for b's state: the one already in the table, or b itself once added
+func (b *Board) GetBoard(boards *Table) *Board {
 	b.CheckEveryFreeSpace(b.Player.X,b.Player.Y)
 
 	newBoard := b
-	boardName := newBoard.GetString()
-	tempBoard := boards[boardName]
-	if tempBoard == nil {
-		boards[boardName] = newBoard
-		newBoard._ResetCanBoxMove()
-	} else {
+	tempBoard := boards._Get(newBoard)
+	if tempBoard != newBoard {
+		tempBoard.search = newBoard.search
 		if tempBoard.Player.X != newBoard.Player.X || tempBoard.Player.Y != newBoard.Player.Y {
 			tempBoard.Player.X = newBoard.Player.X
 			tempBoard.Player.Y = newBoard.Player.Y
@@ -594,11 +631,17 @@ func (b *Board) GetBoard(boards map[string]*Board) *Board {
 	return newBoard
 }
 
-func (b *Board) GetOldMoveBox(x,y int, dir direction.Direction, boards map[string]*Board) *Board {
+func (b *Board) GetOldMoveBox(x,y int, dir direction.Direction, boards *Table) *Board {
 	box := &b.Boxes[b.Get(x,y).Box]
 	var tempBoard *Board
 	if box.DirBoards[dir] != nil { tempBoard = box.DirBoards[dir] }
+	// evicted from the table, it has to be made again
+	if tempBoard != nil && tempBoard.Cells == nil {
+		box.DirBoards[dir] = nil
+		return nil
+	}
 	if tempBoard != nil {
+		tempBoard.search = b.search
 		tempBoard.Player.X = x
 		tempBoard.Player.Y = y
 		tempBoard.CheckEveryDist(tempBoard.Player.X,tempBoard.Player.Y)
@@ -608,7 +651,7 @@ func (b *Board) GetOldMoveBox(x,y int, dir direction.Direction, boards map[strin
 }
 
 // assume x,y is a box
-func (b *Board) MakeMoveBox(x,y int, dir direction.Direction, boards map[string]*Board) *Board {
+func (b *Board) MakeMoveBox(x,y int, dir direction.Direction, boards *Table) *Board {
 	box := &b.Boxes[b.Get(x,y).Box]
 	newBoard := b.Duplicate()
 	newBoard.MoveBox(x,y,dir)
@@ -618,7 +661,7 @@ func (b *Board) MakeMoveBox(x,y int, dir direction.Direction, boards map[string]
 }
 
 // assume it
-func (b *Board) MoveBoxAndCheck(x,y int, dir direction.Direction, boards map[string]*Board) *Board {
+func (b *Board) MoveBoxAndCheck(x,y int, dir direction.Direction, boards *Table) *Board {
 	tempboard := b.GetOldMoveBox(x,y,dir,boards)
 	if tempboard != nil { return tempboard }
 
@@ -641,7 +684,7 @@ func (b *Board) _FindReverseBestPath(x,y int, p Position, l int, pathDir directi
 		return true 
 	}
 
-	if b.Get(x,y).IsFree && b.Get(x,y).Dist[p]==l {
+	if b.Get(x,y).IsFree && b.GetDist(p,x,y)==l {
 		b.Get(x,y).IsPath = true
 		b.Get(x,y).PathDir = pathDir
 		
@@ -665,7 +708,7 @@ func (b *Board) FindBestPath() {
 	x := bestposition.BestX
 	y := bestposition.BestY
 
-	if bestposition.BestLength==0 { return }
+	if bestposition.BestLength==0 || bestposition.BestX==-1 { return }
 
 	switch(bestposition.BestDir) {
 		case direction.L :  x = x+1
@@ -673,7 +716,7 @@ func (b *Board) FindBestPath() {
 		case direction.U :  y = y+1
 		case direction.D :  y = y-1
 	}
-	l := b.Get(x,y).Dist[position]
+	l := b.GetDist(position,x,y)
 
 	b._FindReverseBestPath(x,y,position,l,bestposition.BestDir)
 }
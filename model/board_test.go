@@ -3,6 +3,7 @@ package model
 import (
 	"testing"
 
+	"github.com/TheInvader360/sokoban-go/direction"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,3 +75,57 @@ func TestIsComplete(t *testing.T) {
 	b = NewBoard(mapData, 5, 3)
 	assert.True(t, b.IsComplete())
 }
+
+func TestIsPush(t *testing.T) {
+	b := NewBoard(""+
+		"#####"+
+		"#@$$#"+
+		"#####", 5, 3)
+	assert.False(t, b.IsPush(direction.U))
+	assert.False(t, b.IsPush(direction.L))
+	// a box is pushed (or blocked by the one behind it) whatever lies behind it
+	assert.True(t, b.IsPush(direction.R))
+}
+
+// openMapData - The large open level (22x14) kept commented out in the level manager
+var openMapData = "" +
+	"######################" +
+	"#                 @$.#" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"#                    #" +
+	"######################"
+
+func BenchmarkCheckEveryFreeSpace(b *testing.B) {
+	board := NewBoard(openMapData, 22, 14)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for y := 1; y < 13; y++ {
+			for x := 1; x < 19; x++ {
+				board.CheckEveryFreeSpace(x, y)
+			}
+		}
+		board = board.Duplicate()
+	}
+}
+
+func BenchmarkCheckEveryBoxMoveFromPlayer(b *testing.B) {
+	lm := NewLevelManager(false)
+	lm.ProgressToNextLevel()
+	l := lm.GetCurrentLevel()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board := NewBoard(l.MapData, l.Width, l.Height)
+		board.CheckEveryBoxMoveFromPlayer(NewTable(0))
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+// CorralCells - The cells a PI-corral search looks at, by index (y*width+x). The board search and the solver each give their own view of a state
+type CorralCells interface {
+	Wall(i int) bool
+	Goal(i int) bool
+	Box(i int) bool
+	Reachable(i int) bool // the player can walk there from where it stands
+	Dead(i int) bool      // a box there can never reach a goal (see DeadCells)
+}
+
+// FindPICorral - Looks for a PI-corral on a width x height board: an area the player cannot enter, fenced by boxes that can only be pushed into it (I) and that the player can push from where it stands (P), with work left inside (a box off goal or an empty goal).
+// Such a corral has to be opened before anything else matters, so it returns the cells of the boxes fencing the one needing the fewest pushes, which a search can be restricted to (nil if there is no PI-corral).
+// The second value is true if a PI-corral has no push at all: it can never be opened, the board is dead
+func FindPICorral(c CorralCells, width, height int) ([]int, bool) {
+	f := corralFinder{c: c, width: width, height: height}
+	var best []int
+	bestPushes := 0
+	seen := make([]bool, width*height)
+	for i := range seen {
+		if seen[i] || !f.isCorralCell(i) {
+			continue
+		}
+		area, fence := f.fill(i, seen)
+		pushes, ok := f.countPushes(area, fence)
+		if !ok || !f.isUnfinished(area, fence) {
+			continue
+		}
+		if pushes == 0 {
+			return nil, true
+		}
+		if best == nil || pushes < bestPushes {
+			best = fence
+			bestPushes = pushes
+		}
+	}
+	return best, false
+}
+
+// corralFinder - The state FindPICorral walks through
+type corralFinder struct {
+	c             CorralCells
+	width, height int
+}
+
+// isFloor - Returns true if x,y is on the board and not a wall
+func (f corralFinder) isFloor(x, y int) bool {
+	return x >= 0 && y >= 0 && x < f.width && y < f.height && !f.c.Wall((y*f.width)+x)
+}
+
+// isCorralCell - Returns true if cell i is floor the player cannot reach
+func (f corralFinder) isCorralCell(i int) bool {
+	return !f.c.Wall(i) && !f.c.Box(i) && !f.c.Reachable(i)
+}
+
+// fill - Flood fills the corral around cell i, returns its cells (as a set by index) and the boxes fencing it (by cell index)
+func (f corralFinder) fill(i int, seen []bool) (map[int]bool, []int) {
+	area := map[int]bool{i: true}
+	fence := []int{}
+	inFence := make(map[int]bool)
+	seen[i] = true
+	queue := []int{i}
+	for head := 0; head < len(queue); head++ {
+		x, y := queue[head]%f.width, queue[head]/f.width
+		for _, d := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+			if !f.isFloor(x+d[0], y+d[1]) {
+				continue
+			}
+			n := ((y + d[1]) * f.width) + x + d[0]
+			if f.c.Box(n) && !inFence[n] {
+				inFence[n] = true
+				fence = append(fence, n)
+			} else if !seen[n] && f.isCorralCell(n) {
+				seen[n] = true
+				area[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return area, fence
+}
+
+// countPushes - Counts the pushes of the fence boxes into the corral, returns false if the corral is not a PI-corral (a push leads out of it, or a push into it cannot be reached by the player)
+func (f corralFinder) countPushes(area map[int]bool, fence []int) (int, bool) {
+	pushes := 0
+	for _, box := range fence {
+		x, y := box%f.width, box/f.width
+		for _, d := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+			toX, toY := x+d[0], y+d[1]
+			fromX, fromY := x-d[0], y-d[1]
+			to, from := (toY*f.width)+toX, (fromY*f.width)+fromX
+			if !f.isFloor(toX, toY) || f.c.Box(to) || !f.isFloor(fromX, fromY) {
+				continue
+			}
+			// a box standing where the player would push from counts as unreachable: it may move away later
+			reachable := f.c.Reachable(from) && !f.c.Box(from)
+			if area[to] {
+				if !reachable {
+					return 0, false
+				}
+				if !f.c.Dead(to) {
+					pushes++
+				}
+			} else if reachable {
+				return 0, false
+			}
+		}
+	}
+	return pushes, true
+}
+
+// isUnfinished - Returns true if a fence box is off goal or a goal in the corral has no box
+func (f corralFinder) isUnfinished(area map[int]bool, fence []int) bool {
+	for _, box := range fence {
+		if !f.c.Goal(box) {
+			return true
+		}
+	}
+	for i := range area {
+		if f.c.Goal(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// boardCorral - The board as FindPICorral sees it, the player reach being the free space of CheckEveryFreeSpace
+type boardCorral struct{ b *Board }
+
+func (c boardCorral) Wall(i int) bool      { return c.b.Cells[i].TypeOf == CellTypeWall }
+func (c boardCorral) Goal(i int) bool      { return c.b.Cells[i].TypeOf == CellTypeGoal }
+func (c boardCorral) Box(i int) bool       { return c.b.Cells[i].HasBox }
+func (c boardCorral) Reachable(i int) bool { return c.b.Cells[i].IsFree }
+func (c boardCorral) Dead(i int) bool      { return c.b.DeadCells[i] }
+
+// _FindPICorral - FindPICorral on the board: returns the boxes (by index) the search can be restricted to, nil for every box, and true if the board is dead
+func (b *Board) _FindPICorral() ([]bool, bool) {
+	fence, closed := FindPICorral(boardCorral{b}, b.Width, b.Height)
+	if fence == nil {
+		return nil, closed
+	}
+	corral := make([]bool, len(b.Boxes))
+	for _, box := range fence {
+		corral[b.Cells[box].Box] = true
+	}
+	return corral, false
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPICorral(t *testing.T) {
+	// the box fences a room the player can only open by pushing it in
+	rows := []string{
+		"#########",
+		"#   #   #",
+		"# $ #   #",
+		"#@  $ . #",
+		"#.  #   #",
+		"#########"}
+	b := NewBoard(strings.Join(rows, ""), len(rows[0]), len(rows))
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	corral, closed := b._FindPICorral()
+	assert.False(t, closed)
+	assert.True(t, corral[b.Get(4, 3).Box])
+	assert.False(t, corral[b.Get(2, 2).Box])
+	fence, _ := FindPICorral(boardCorral{b}, b.Width, b.Height)
+	assert.Equal(t, []int{(3 * b.Width) + 4}, fence)
+	// restricted to that push or not, the search finds the level solvable
+	for _, restrict := range []bool{false, true} {
+		boards := NewTable(0)
+		boards.PICorrals = restrict
+		searched := NewBoard(strings.Join(rows, ""), len(rows[0]), len(rows))
+		searched.CheckEveryBoxMoveFromPlayer(boards)
+		assert.Less(t, searched.GetBestPosition().BestLength, 999)
+	}
+
+	// the only push into the corral lands in a corner: it can never be opened
+	rows = []string{
+		"#######",
+		"#.@ $ #",
+		"#######"}
+	b = NewBoard(strings.Join(rows, ""), len(rows[0]), len(rows))
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	_, closed = b._FindPICorral()
+	assert.True(t, closed)
+	b.CheckEveryBoxMoveFromPlayer(NewTable(0))
+	assert.Equal(t, 999, b.GetBestPosition().BestLength)
+
+	// nothing left to do in a corral of boxes on goals
+	rows = []string{
+		"#######",
+		"#@ *  #",
+		"#######"}
+	b = NewBoard(strings.Join(rows, ""), len(rows[0]), len(rows))
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	corral, closed = b._FindPICorral()
+	assert.False(t, closed)
+	assert.Nil(t, corral)
+}
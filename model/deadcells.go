@@ -0,0 +1,57 @@
+package model
+
+// _FindDeadCells - Pulls a box backwards from every goal (ignoring the other boxes) and marks the floor cells it never reaches: a box pushed there can no longer get to any goal
+func (b *Board) _FindDeadCells() {
+	live := make([]bool, len(b.Cells))
+	queue := []int{}
+	for i, cell := range b.Cells {
+		if cell.TypeOf == CellTypeGoal {
+			live[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for head := 0; head < len(queue); head++ {
+		x, y := queue[head]%b.Width, queue[head]/b.Width
+		for _, d := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+			// the box came from x+dx,y+dy, pushed by a player standing one cell further
+			fromX, fromY := x+d[0], y+d[1]
+			if !b._IsFloor(fromX, fromY) || !b._IsFloor(fromX+d[0], fromY+d[1]) || live[(fromY*b.Width)+fromX] {
+				continue
+			}
+			live[(fromY*b.Width)+fromX] = true
+			queue = append(queue, (fromY*b.Width)+fromX)
+		}
+	}
+
+	b.DeadCells = make([]bool, len(b.Cells))
+	for i, cell := range b.Cells {
+		b.DeadCells[i] = cell.TypeOf != CellTypeWall && !live[i]
+	}
+}
+
+// _CheckEveryBoxIsOnDeadCell - Marks the boxes standing on a dead cell, returns true if there is any
+func (b *Board) _CheckEveryBoxIsOnDeadCell() bool {
+	dead := false
+	for i := range b.Boxes {
+		if b.IsDeadCell(b.Boxes[i].X, b.Boxes[i].Y) {
+			b.Boxes[i].IsDead = true
+			dead = true
+		}
+	}
+	return dead
+}
+
+// _IsFloor - Returns true if x,y is on the board and not a wall
+func (b *Board) _IsFloor(x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.Width && y < b.Height && b.Get(x, y).TypeOf != CellTypeWall
+}
+
+// IsDeadCell - Returns true if a box at x,y can never reach a goal (see _FindDeadCells)
+func (b *Board) IsDeadCell(x, y int) bool {
+	return b.DeadCells[(y*b.Width)+x]
+}
+
+// DeadCells - Returns the cells (by index) from which a box can never reach a goal
+func (l *Level) DeadCells() []bool {
+	return NewBoard(l.MapData, l.Width, l.Height).DeadCells
+}
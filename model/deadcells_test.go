@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadCells(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#     #" +
+		"# @$ .#" +
+		"#     #" +
+		"#######"
+	b := NewBoard(mapData, 7, 5)
+
+	// only the middle row, from the second column on, can lead a box to the goal
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			live := y == 2 && x >= 2 && x <= 5
+			wall := b.Get(x, y).TypeOf == CellTypeWall
+			assert.Equal(t, !live && !wall, b.IsDeadCell(x, y), "%d,%d", x, y)
+		}
+	}
+	assert.Equal(t, b.DeadCells, b.Duplicate().DeadCells)
+	l := Level{Width: 7, Height: 5, MapData: mapData}
+	assert.Equal(t, b.DeadCells, l.DeadCells())
+
+	// pushes onto dead cells are pruned
+	b.CheckEveryBoxMoveFromPlayer(NewTable(0))
+	box := b.Boxes[0]
+	assert.True(t, box.CanMove[direction.U])
+	assert.True(t, box.ShallNotMove[direction.U])
+	assert.True(t, box.CanMove[direction.D])
+	assert.True(t, box.ShallNotMove[direction.D])
+	assert.False(t, box.ShallNotMove[direction.R])
+	assert.Equal(t, 2, b.GetBestPosition().BestLength)
+
+	// a box standing on a dead cell is dead
+	mapData = "" +
+		"#######" +
+		"#  $  #" +
+		"# @  .#" +
+		"#######"
+	b = NewBoard(mapData, 7, 4)
+	b.CheckEveryBoxMoveFromPlayer(NewTable(0))
+	assert.True(t, b.Boxes[0].IsDead)
+	assert.Equal(t, 999, b.GetBestPosition().BestLength)
+}
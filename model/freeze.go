@@ -0,0 +1,90 @@
+package model
+
+// IsFreezeDeadlock - Returns true if the box at x,y can never move again (on both axes, through walls, dead cells or other frozen boxes) while it, or a box frozen with it, is off goal
+func (b *Board) IsFreezeDeadlock(x, y int) bool {
+	frozen, offGoal := b._IsBoxFrozen(x, y, make(map[int]bool))
+	return frozen && offGoal
+}
+
+// _IsBoxFrozen - Returns true if the box at x,y is blocked on both axes, and whether it or a box it is frozen by is off goal. The boxes in walls count as walls (the ones being checked, which breaks cycles)
+func (b *Board) _IsBoxFrozen(x, y int, walls map[int]bool) (bool, bool) {
+	i := (y * b.Width) + x
+	walls[i] = true
+	defer delete(walls, i)
+
+	blockedX, offGoalX := b._IsBoxBlocked(x, y, 1, 0, walls)
+	if !blockedX {
+		return false, false
+	}
+	blockedY, offGoalY := b._IsBoxBlocked(x, y, 0, 1, walls)
+	if !blockedY {
+		return false, false
+	}
+	return true, offGoalX || offGoalY || b.Cells[i].TypeOf != CellTypeGoal
+}
+
+// _IsBoxBlocked - Returns true if the box at x,y cannot move along the dx,dy axis (a wall on either side, dead cells on both sides, or a frozen box on either side), and whether the frozen box is off goal
+func (b *Board) _IsBoxBlocked(x, y, dx, dy int, walls map[int]bool) (bool, bool) {
+	beforeX, beforeY := x-dx, y-dy
+	afterX, afterY := x+dx, y+dy
+	if b._IsWallFor(beforeX, beforeY, walls) || b._IsWallFor(afterX, afterY, walls) {
+		return true, false
+	}
+	if b.IsDeadCell(beforeX, beforeY) && b.IsDeadCell(afterX, afterY) {
+		return true, false
+	}
+	if b.Get(beforeX, beforeY).HasBox {
+		if frozen, offGoal := b._IsBoxFrozen(beforeX, beforeY, walls); frozen {
+			return true, offGoal
+		}
+	}
+	if b.Get(afterX, afterY).HasBox {
+		if frozen, offGoal := b._IsBoxFrozen(afterX, afterY, walls); frozen {
+			return true, offGoal
+		}
+	}
+	return false, false
+}
+
+// _IsWallFor - Returns true if x,y is off the board, a wall, or a box counted as a wall
+func (b *Board) _IsWallFor(x, y int, walls map[int]bool) bool {
+	return !b._IsFloor(x, y) || walls[(y*b.Width)+x]
+}
+
+// IsBlockDeadlock - Returns true if the box at x,y is part of a 2x2 square made of boxes and walls with a box off goal (no box of it can ever move)
+func (b *Board) IsBlockDeadlock(x, y int) bool {
+	for _, corner := range [4][2]int{{-1, -1}, {0, -1}, {-1, 0}, {0, 0}} {
+		blocked, offGoal := true, false
+		for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+			cx, cy := x+corner[0]+d[0], y+corner[1]+d[1]
+			if !b._IsFloor(cx, cy) {
+				continue
+			}
+			c := b.Get(cx, cy)
+			if !c.HasBox {
+				blocked = false
+				break
+			}
+			if c.TypeOf != CellTypeGoal {
+				offGoal = true
+			}
+		}
+		if blocked && offGoal {
+			return true
+		}
+	}
+	return false
+}
+
+// _CheckEveryBoxIsFrozen - Marks the boxes caught in a block or freeze deadlock, returns true if there is any
+func (b *Board) _CheckEveryBoxIsFrozen() bool {
+	frozen := false
+	for i := range b.Boxes {
+		x, y := b.Boxes[i].X, b.Boxes[i].Y
+		if b.IsBlockDeadlock(x, y) || b.IsFreezeDeadlock(x, y) {
+			b.Boxes[i].IsDead = true
+			frozen = true
+		}
+	}
+	return frozen
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadlocks(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   []string
+		freeze bool
+		block  bool
+	}{
+		{"single box", []string{
+			"#######",
+			"#     #",
+			"#  $  #",
+			"#    .#",
+			"#######"}, false, false},
+		{"boxes side by side", []string{
+			"#######",
+			"#     #",
+			"# $$  #",
+			"#   ..#",
+			"#######"}, false, false},
+		{"2x2 boxes", []string{
+			"########",
+			"#      #",
+			"# $$   #",
+			"# $$   #",
+			"#  ....#",
+			"########"}, true, true},
+		{"2x2 boxes on goals", []string{
+			"########",
+			"#      #",
+			"# **   #",
+			"# **   #",
+			"#      #",
+			"########"}, false, false},
+		{"2x2 boxes and walls", []string{
+			"#######",
+			"#     #",
+			"# #$  #",
+			"# $#  #",
+			"#   ..#",
+			"#######"}, true, true},
+		{"boxes side by side along a wall", []string{
+			"#######",
+			"# $$  #",
+			"#     #",
+			"#  .. #",
+			"#######"}, true, true},
+		{"boxes side by side along a wall on goals", []string{
+			"#######",
+			"# **  #",
+			"#     #",
+			"#     #",
+			"#######"}, false, false},
+		{"Z shape", []string{
+			"#########",
+			"#       #",
+			"#  $#   #",
+			"# #$    #",
+			"#   ..  #",
+			"#########"}, true, false},
+		{"Z shape with a box on goal", []string{
+			"#########",
+			"#       #",
+			"#  *#   #",
+			"# #$    #",
+			"#   .   #",
+			"#########"}, true, false},
+		{"Z shape on goals", []string{
+			"#########",
+			"#       #",
+			"#  *#   #",
+			"# #*    #",
+			"#       #",
+			"#########"}, false, false},
+		{"Z shape with room to move", []string{
+			"#########",
+			"#       #",
+			"#  $#   #",
+			"#  $    #",
+			"#   ..  #",
+			"#########"}, false, false},
+		{"box between dead cells along a wall", []string{
+			"#######",
+			"#  $  #",
+			"#     #",
+			"# .   #",
+			"#######"}, true, false},
+		{"box and frozen neighbour on goal", []string{
+			"#######",
+			"#     #",
+			"#$*   #",
+			"#    .#",
+			"#######"}, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := NewBoard(strings.Join(test.rows, ""), len(test.rows[0]), len(test.rows))
+			freeze, block := false, false
+			for _, box := range b.Boxes {
+				freeze = freeze || b.IsFreezeDeadlock(box.X, box.Y)
+				block = block || b.IsBlockDeadlock(box.X, box.Y)
+			}
+			assert.Equal(t, test.freeze, freeze, "freeze")
+			assert.Equal(t, test.block, block, "block")
+			assert.Equal(t, test.freeze || test.block, b._CheckEveryBoxIsFrozen(), "marked dead")
+		})
+	}
+}
@@ -1,14 +1,22 @@
 package model
 
+import "github.com/TheInvader360/sokoban-go/direction"
+
 type LastMove struct {
 	LastX, LastY int
 	LastTargetX, LastTargetY int
 	LastNextX, LastNextY int
+	Dir direction.Direction
 
 	PreviousMove *LastMove
 }
 
 // LastMove - Memorize the last move and effect on board
-func NewLastMove(x, y int, lasttargetX, lasttargetY int, lastnextX, lastnextY int, lastMove *LastMove) *LastMove {
-	return &LastMove{LastX: x, LastY: y, LastTargetX: lasttargetX, LastTargetY: lasttargetY, LastNextX: lastnextX, LastNextY: lastnextY, PreviousMove: lastMove}
+func NewLastMove(x, y int, lasttargetX, lasttargetY int, lastnextX, lastnextY int, dir direction.Direction, lastMove *LastMove) *LastMove {
+	return &LastMove{LastX: x, LastY: y, LastTargetX: lasttargetX, LastTargetY: lasttargetY, LastNextX: lastnextX, LastNextY: lastnextY, Dir: dir, PreviousMove: lastMove}
+}
+
+// IsPush - Returns true if the move pushed a box
+func (lm *LastMove) IsPush() bool {
+	return lm.LastTargetX != -1
 }
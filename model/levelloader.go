@@ -0,0 +1,234 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// levelFileExtensions - File extensions picked up when loading levels from a directory (a pack in another file, e.g. .txt, is loaded by naming the file itself)
+var levelFileExtensions = []string{".xsb", ".sok"}
+
+// levelParser - Accumulates the lines of a level pack into levels
+type levelParser struct {
+	levels   []Level
+	rows     []string
+	rowsDone bool
+	current  Level
+	pending  Level
+	line     int
+}
+
+// ParseLevels - Parses a level pack in the XSB/SOK text format (";" comments, "Title:"/"Author:" metadata, blank line separated levels, ragged rows)
+func ParseLevels(r io.Reader) ([]Level, error) {
+	p := levelParser{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.line++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := p.finishLevel(); err != nil {
+		return nil, err
+	}
+	return p.levels, nil
+}
+
+// LoadLevels - Loads the levels of a pack file, or of every pack file in a directory (in file name order)
+func LoadLevels(path string) ([]Level, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var levels []Level
+	if info.IsDir() {
+		levels, err = loadLevelDir(path)
+	} else {
+		levels, err = loadLevelFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("%s: no levels found", path)
+	}
+	return levels, nil
+}
+
+// loadLevelDir - Loads the levels of every pack file in a directory (in file name order)
+func loadLevelDir(path string) ([]Level, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && isLevelFile(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	levels := []Level{}
+	for _, name := range names {
+		fileLevels, err := loadLevelFile(filepath.Join(path, name))
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, fileLevels...)
+	}
+	return levels, nil
+}
+
+func loadLevelFile(path string) ([]Level, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	levels, err := ParseLevels(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return levels, nil
+}
+
+func isLevelFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range levelFileExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// isBoardRow - Returns true if the line only holds board characters (and at least one wall)
+func isBoardRow(line string) bool {
+	if !strings.Contains(line, "#") {
+		return false
+	}
+	for _, r := range line {
+		if !strings.ContainsRune("#@+$*. -_pPbB", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// normaliseBoardRow - Maps the alternative floor/player/box characters onto the ones NewBoard understands
+func normaliseBoardRow(line string) string {
+	return strings.NewReplacer("-", " ", "_", " ", "p", "@", "P", "+", "b", "$", "B", "*").Replace(line)
+}
+
+func (p *levelParser) parseLine(line string) error {
+	line = strings.TrimRight(line, " \t\r")
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(line), ";"):
+		// comment
+	case line == "":
+		if len(p.rows) > 0 {
+			p.rowsDone = true
+		}
+	case isBoardRow(line):
+		if p.rowsDone {
+			if err := p.finishLevel(); err != nil {
+				return err
+			}
+		}
+		if len(p.rows) == 0 {
+			p.current = p.pending
+			p.pending = Level{}
+		}
+		p.rows = append(p.rows, normaliseBoardRow(line))
+	default:
+		if len(p.rows) > 0 {
+			p.rowsDone = true
+		}
+		key, value, found := strings.Cut(line, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if found && key == "title" {
+			if len(p.rows) > 0 && p.current.Title == "" {
+				p.current.Title = value
+			} else {
+				p.pending.Title = value
+			}
+		} else if found && key == "author" {
+			if len(p.rows) > 0 && p.current.Author == "" {
+				p.current.Author = value
+			} else {
+				p.pending.Author = value
+			}
+		} else if !found && p.pending.Title == "" {
+			// a bare line of text names the level that follows it (SOK style)
+			p.pending.Title = strings.TrimSpace(line)
+		}
+	}
+	return nil
+}
+
+// finishLevel - Pads the accumulated rows into a rectangular map and validates the level
+func (p *levelParser) finishLevel() error {
+	if len(p.rows) == 0 {
+		return nil
+	}
+
+	l := newLevelFromRows(p.rows)
+	l.Title = p.current.Title
+	l.Author = p.current.Author
+
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("level %d (ending line %d): %w", len(p.levels)+1, p.line, err)
+	}
+
+	p.levels = append(p.levels, l)
+	p.rows = nil
+	p.rowsDone = false
+	p.current = Level{}
+	return nil
+}
+
+// newLevelFromRows - Creates a level from its rows, padding ragged rows with floor to the widest one
+func newLevelFromRows(rows []string) Level {
+	l := Level{Height: len(rows)}
+	for _, row := range rows {
+		if len(row) > l.Width {
+			l.Width = len(row)
+		}
+	}
+	var mapData strings.Builder
+	for _, row := range rows {
+		mapData.WriteString(row)
+		mapData.WriteString(strings.Repeat(" ", l.Width-len(row)))
+	}
+	l.MapData = mapData.String()
+	return l
+}
+
+// Validate - Returns an error if the level does not have exactly one player and as many boxes as goals
+func (l *Level) Validate() error {
+	if len(l.MapData) != l.Width*l.Height {
+		return fmt.Errorf("map data holds %d cells, expected %dx%d", len(l.MapData), l.Width, l.Height)
+	}
+	players := strings.Count(l.MapData, "@") + strings.Count(l.MapData, "+")
+	boxes := strings.Count(l.MapData, "$") + strings.Count(l.MapData, "*")
+	goals := strings.Count(l.MapData, ".") + strings.Count(l.MapData, "+") + strings.Count(l.MapData, "*")
+	if players != 1 {
+		return fmt.Errorf("found %d players, expected 1", players)
+	}
+	if boxes == 0 || boxes != goals {
+		return fmt.Errorf("found %d boxes and %d goals", boxes, goals)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const xsbPack = `; a comment line
+
+  ####
+###  #
+#@$ .#
+#####
+Title: Ragged
+Author: Someone
+
+; another comment
+#####
+#+*$ #
+######
+Title: Second
+`
+
+func TestParseLevels(t *testing.T) {
+	levels, err := ParseLevels(strings.NewReader(xsbPack))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(levels))
+
+	// ragged rows are padded to the widest row
+	assert.Equal(t, 6, levels[0].Width)
+	assert.Equal(t, 4, levels[0].Height)
+	assert.Equal(t, "  #######  ##@$ .###### ", levels[0].MapData)
+	assert.Equal(t, "Ragged", levels[0].Title)
+	assert.Equal(t, "Someone", levels[0].Author)
+
+	assert.Equal(t, 6, levels[1].Width)
+	assert.Equal(t, 3, levels[1].Height)
+	assert.Equal(t, "##### #+*$ #######", levels[1].MapData)
+	assert.Equal(t, "Second", levels[1].Title)
+	assert.Equal(t, "", levels[1].Author)
+
+	// the padded map data can be handed over to NewBoard
+	b := NewBoard(levels[0].MapData, levels[0].Width, levels[0].Height)
+	assert.Equal(t, 1, b.Player.X)
+	assert.Equal(t, 2, b.Player.Y)
+	assert.True(t, b.Get(2, 2).HasBox)
+}
+
+func TestParseLevelsSokStyle(t *testing.T) {
+	pack := "Level One\n\n----#\n#@$.#\n#####\n\nLevel Two\n#####\n#p_b.#\n######\n"
+	levels, err := ParseLevels(strings.NewReader(pack))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(levels))
+	assert.Equal(t, "Level One", levels[0].Title)
+	assert.Equal(t, "    ##@$.######", levels[0].MapData)
+	assert.Equal(t, "Level Two", levels[1].Title)
+	assert.Equal(t, "##### #@ $.#######", levels[1].MapData)
+}
+
+func TestParseLevelsInvalid(t *testing.T) {
+	_, err := ParseLevels(strings.NewReader("#####\n#@$ #\n#####\n"))
+	assert.Error(t, err)
+
+	_, err = ParseLevels(strings.NewReader("######\n#@$.@#\n######\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadLevels(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.sok"), []byte("#####\n#@$.#\n#####\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.xsb"), []byte(xsbPack), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("not a pack"), 0644))
+	// a text file reads as a pack if it holds a line of walls, it only loads when named
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("Notes\n#####\n#@$.#\n#####\n"), 0644))
+
+	levels, err := LoadLevels(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(levels))
+	assert.Equal(t, "Ragged", levels[0].Title)
+	assert.Equal(t, "######@$.######", levels[2].MapData)
+
+	levels, err = LoadLevels(filepath.Join(dir, "readme.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(levels))
+
+	lm, err := LoadLevelManager(filepath.Join(dir, "a.xsb"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lm.GetFinalLevelNumber())
+	lm.ProgressToNextLevel()
+	assert.Equal(t, "Ragged", lm.GetCurrentLevel().Title)
+
+	// the pack path is kept absolute, so that a save game finds it from any directory
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	rel, err := filepath.Rel(wd, filepath.Join(dir, "a.xsb"))
+	assert.NoError(t, err)
+	lm, err = LoadLevelManager(rel)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "a.xsb"), lm.GetPath())
+
+	// packs are told apart by name and path
+	assert.Regexp(t, `^a-[0-9a-f]{8}$`, lm.GetPackName())
+	other, err := LoadLevelManager(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, lm.GetPackName(), other.GetPackName())
+	assert.Equal(t, "", NewLevelManager(false).GetPackName())
+
+	_, err = LoadLevels(filepath.Join(dir, "missing.xsb"))
+	assert.Error(t, err)
+
+	// a file or directory without levels is an error, not an empty pack
+	_, err = LoadLevels(filepath.Join(dir, "notes.md"))
+	assert.EqualError(t, err, filepath.Join(dir, "notes.md")+": no levels found")
+	_, err = LoadLevels(t.TempDir())
+	assert.Error(t, err)
+}
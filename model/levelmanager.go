@@ -1,13 +1,22 @@
 package model
 
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+)
+
 type LevelManager struct {
 	currentLevelNumber int
 	levels             []Level
+	path               string
 }
 
 type Level struct {
 	Width, Height int
 	MapData       string // Player "@", Box "$", Goal ".", Wall "#", Goal+Player "+", Goal+Box "*", None " ")
+	Title, Author string
 }
 
 // NewLevelManager - Creates a level manager
@@ -287,6 +296,43 @@ func NewLevelManager(testMode bool) *LevelManager {
 	return &lm
 }
 
+// NewLevelManagerFromLevels - Creates a level manager playing the given levels (level 1 is levels[0])
+func NewLevelManagerFromLevels(levels []Level) *LevelManager {
+	lm := LevelManager{}
+	lm.levels = append([]Level{{}}, levels...)
+	return &lm
+}
+
+// LoadLevelManager - Creates a level manager playing the levels of a pack file or directory (see LoadLevels)
+func LoadLevelManager(path string) (*LevelManager, error) {
+	levels, err := LoadLevels(path)
+	if err != nil {
+		return nil, err
+	}
+	lm := NewLevelManagerFromLevels(levels)
+	if lm.path, err = filepath.Abs(path); err != nil {
+		return nil, err
+	}
+	return lm, nil
+}
+
+// GetPath - Returns the absolute level pack path the levels were loaded from (empty for the built-in levels)
+func (lm *LevelManager) GetPath() string {
+	return lm.path
+}
+
+// GetPackName - Returns a name telling the level pack apart, e.g. for the directory of its solutions: its file name and a hash of its absolute path,
+// so that two packs of the same name do not share it (empty for the built-in levels)
+func (lm *LevelManager) GetPackName() string {
+	if lm.path == "" {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(lm.path))
+	base := filepath.Base(lm.path)
+	return fmt.Sprintf("%s-%08x", strings.TrimSuffix(base, filepath.Ext(base)), h.Sum32())
+}
+
 // GetCurrentLevelNumber - Returns the current level number
 func (lm *LevelManager) GetCurrentLevelNumber() int {
 	return lm.currentLevelNumber
@@ -314,6 +360,15 @@ func (lm *LevelManager) ProgressToNextLevel() {
 	lm.currentLevelNumber++
 }
 
+// SetCurrentLevelNumber - Sets the current level, returns false if there is no such level
+func (lm *LevelManager) SetCurrentLevelNumber(n int) bool {
+	if n < 1 || n > lm.GetFinalLevelNumber() {
+		return false
+	}
+	lm.currentLevelNumber = n
+	return true
+}
+
 // Reset - Resets the level manager
 func (lm *LevelManager) Reset() {
 	lm.currentLevelNumber = 0
@@ -42,3 +42,12 @@ func TestLevelManager(t *testing.T) {
 	assert.Equal(t, 0, lm.GetCurrentLevel().Height)
 	assert.Equal(t, "", lm.GetCurrentLevel().MapData)
 }
+
+func TestSetCurrentLevelNumber(t *testing.T) {
+	lm := NewLevelManager(true)
+	assert.True(t, lm.SetCurrentLevelNumber(3))
+	assert.Equal(t, 3, lm.GetCurrentLevelNumber())
+	assert.False(t, lm.SetCurrentLevelNumber(0))
+	assert.False(t, lm.SetCurrentLevelNumber(4))
+	assert.Equal(t, 3, lm.GetCurrentLevelNumber())
+}
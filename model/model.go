@@ -12,23 +12,68 @@ const (
 	StateGameComplete
 )
 
+// HintBackend - The search behind the hints and autoplay
+type HintBackend int
+
+const (
+	HintBackendBoard  HintBackend = iota // the board's own depth first search
+	HintBackendSolver                    // the push-optimal A* search of the solver package
+)
+
+// String - Returns the backend name shown in the view
+func (h HintBackend) String() string {
+	if h == HintBackendSolver {
+		return "A*"
+	}
+	return "Board"
+}
+
+// Objective - What the solver minimises
+type Objective int
+
+const (
+	ObjectivePushes      Objective = iota // the fewest pushes, whatever the walks
+	ObjectiveMoves                        // the fewest moves, walks and pushes alike
+	ObjectivePushesMoves                  // the fewest pushes, then the fewest moves among them
+)
+
+// String - Returns the objective name shown in the view
+func (o Objective) String() string {
+	switch o {
+	case ObjectiveMoves:
+		return "Moves"
+	case ObjectivePushesMoves:
+		return "Push+Mov"
+	}
+	return "Pushes"
+}
+
+// MaxZoom - The most zoom steps in or out of the size fitting the board in the view
+const MaxZoom = 7
+
 type Model struct {
 	LM             *LevelManager
 	Board          *Board
-	Boards		map[string]*Board
+	Boards		*Table // searched boards, owned by the hint search while one runs
 	LastMove       *LastMove
+	RedoMove       *LastMove
 	State           state
 	TickAccumulator int
 	Moves		int
 	BestMoves	int
 	SolveDuration	time.Duration
+	Hints		HintBackend
+	Objective	Objective // what the solver backend minimises
+	Search		SearchProgress
+	Notice		string // a message for the player shown until their next action, e.g. that the game was resumed
+	Zoom		int // board tile size steps over the size fitting the board in the view, -MaxZoom to MaxZoom (0 fits it)
 }
 
 // NewModel - Creates a model
 func NewModel() *Model {
 	m := Model{
 		LM: NewLevelManager(false),
-		Boards: make(map[string]*Board)	}
+		Boards: NewTable(DefaultTableBytes)	}
 
 	return &m
 }
@@ -40,3 +85,15 @@ func (m *Model) Update() {
 		m.TickAccumulator = 0
 	}
 }
+
+// GetMoves - Returns the moves played since the start of the level (oldest first)
+func (m *Model) GetMoves() []Move {
+	moves := []Move{}
+	for lm := m.LastMove; lm != nil; lm = lm.PreviousMove {
+		moves = append(moves, Move{Dir: lm.Dir, Push: lm.IsPush()})
+	}
+	for i, j := 0, len(moves)-1; i < j; i, j = i+1, j-1 {
+		moves[i], moves[j] = moves[j], moves[i]
+	}
+	return moves
+}
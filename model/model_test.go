@@ -3,6 +3,7 @@ package model
 import (
 	"testing"
 
+	"github.com/TheInvader360/sokoban-go/direction"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +26,13 @@ func TestUpdate(t *testing.T) {
 	m.Update()
 	assert.Equal(t, 1, m.TickAccumulator)
 }
+
+func TestGetMoves(t *testing.T) {
+	m := NewModel()
+	assert.Equal(t, []Move{}, m.GetMoves())
+
+	m.LastMove = NewLastMove(1, 1, -1, -1, -1, -1, direction.R, m.LastMove)
+	m.LastMove = NewLastMove(2, 1, 3, 1, 4, 1, direction.R, m.LastMove)
+	m.LastMove = NewLastMove(3, 1, -1, -1, -1, -1, direction.U, m.LastMove)
+	assert.Equal(t, []Move{{Dir: direction.R}, {Dir: direction.R, Push: true}, {Dir: direction.U}}, m.GetMoves())
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+)
+
+// Move - A single player step, Push is true when the step pushed a box
+type Move struct {
+	Dir  direction.Direction
+	Push bool
+}
+
+// String - Returns the move in LURD notation (lowercase walk, uppercase push)
+func (mv Move) String() string {
+	letter := [...]string{"u", "d", "l", "r"}[mv.Dir]
+	if mv.Push {
+		return strings.ToUpper(letter)
+	}
+	return letter
+}
+
+// ParseMove - Returns the move matching a LURD letter
+func ParseMove(r rune) (Move, error) {
+	switch r {
+	case 'u', 'U':
+		return Move{Dir: direction.U, Push: r == 'U'}, nil
+	case 'd', 'D':
+		return Move{Dir: direction.D, Push: r == 'D'}, nil
+	case 'l', 'L':
+		return Move{Dir: direction.L, Push: r == 'L'}, nil
+	case 'r', 'R':
+		return Move{Dir: direction.R, Push: r == 'R'}, nil
+	}
+	return Move{}, fmt.Errorf("invalid move %q", r)
+}
+
+// FormatMoves - Returns the moves as a plain LURD string
+func FormatMoves(moves []Move) string {
+	var lurd strings.Builder
+	for _, mv := range moves {
+		lurd.WriteString(mv.String())
+	}
+	return lurd.String()
+}
+
+// Directions - Returns the directions of the given moves
+func Directions(moves []Move) []direction.Direction {
+	dirs := make([]direction.Direction, len(moves))
+	for i, mv := range moves {
+		dirs[i] = mv.Dir
+	}
+	return dirs
+}
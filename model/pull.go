@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/TheInvader360/sokoban-go/direction"
+)
+
+// Pull - A box the player can pull: the box at X,Y goes back one cell against Dir, undoing a push in Dir (see PullBox)
+type Pull struct {
+	X, Y int
+	Dir  direction.Direction
+}
+
+// PullBox - Moves the box at x,y back against dir with the player in front of it, the reverse of MoveBox: PullBox(x,y,dir) undoes MoveBox(x+dx,y+dy,dir)
+func (b *Board) PullBox(x, y int, dir direction.Direction) {
+	Pos := Position{X: b.Player.X, Y: b.Player.Y}
+	b.BestPositions[Pos] = &BestPosition{BestLength: 1000, BestX: -1, BestY: -1}
+
+	dx, dy := getMoveDirection(dir)
+	b.PlaceBox(x, y, x+dx, y+dy)
+	b.Player.X = x + 2*dx
+	b.Player.Y = y + 2*dy
+}
+
+// CanPullBox - Returns true if the player can walk in front of the box at x,y and pull it back against dir: the cell the box goes to is free space (see CheckEveryFreeSpace) and the player has a free cell to step back to
+func (b *Board) CanPullBox(x, y int, dir direction.Direction) bool {
+	dx, dy := getMoveDirection(dir)
+	if !b.Get(x, y).HasBox || !b._IsFloor(x+dx, y+dy) || !b._IsFloor(x+2*dx, y+2*dy) {
+		return false
+	}
+	to := b.Get(x+dx, y+dy)
+	back := b.Get(x+2*dx, y+2*dy)
+	return to.IsFree && !back.HasBox
+}
+
+// GetPulls - Returns every pull the player can make from where it stands, the reverse move generator of a search backwards from the goals
+func (b *Board) GetPulls() []Pull {
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	pulls := []Pull{}
+	for _, box := range b.Boxes {
+		for _, dir := range []direction.Direction{direction.U, direction.D, direction.L, direction.R} {
+			if b.CanPullBox(box.X, box.Y, dir) {
+				pulls = append(pulls, Pull{X: box.X, Y: box.Y, Dir: dir})
+			}
+		}
+	}
+	return pulls
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullBox(t *testing.T) {
+	mapData := "" +
+		"######" +
+		"#    #" +
+		"# $@ #" +
+		"#  . #" +
+		"######"
+	b := NewBoard(mapData, 6, 5)
+	// the player needs a free cell to step back to, next to the wall it has none
+	assert.Equal(t, []Pull{{X: 2, Y: 2, Dir: direction.L}}, b.GetPulls())
+
+	// a pull undoes the push
+	key := b.GetKey()
+	b.PullBox(2, 2, direction.L)
+	assert.True(t, b.Get(3, 2).HasBox)
+	assert.Equal(t, 4, b.Player.X)
+	b.MoveBox(3, 2, direction.L)
+	assert.True(t, b.Get(2, 2).HasBox)
+	assert.Equal(t, key, b.GetKey())
+
+	assert.False(t, b.CanPullBox(2, 2, direction.R))
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRLELength - The longest string DecodeRLE expands to, and so the largest count, keeping a corrupt save or solution file from exhausting the memory
+const maxRLELength = 1 << 20
+
+// EncodeRLE - Run-length encodes a string (e.g. "###  $" becomes "3#2 $")
+func EncodeRLE(s string) string {
+	var out strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		if j-i > 1 {
+			out.WriteString(strconv.Itoa(j - i))
+		}
+		out.WriteRune(runes[i])
+		i = j
+	}
+	return out.String()
+}
+
+// DecodeRLE - Expands a run-length encoded string, a count may also apply to a parenthesised group (e.g. "2(lR)")
+func DecodeRLE(s string) (string, error) {
+	out, rest, err := decodeRLEGroup([]rune(s), false)
+	if err != nil {
+		return "", err
+	}
+	if len(rest) > 0 {
+		return "", fmt.Errorf("unexpected %q", rest[0])
+	}
+	return out, nil
+}
+
+// decodeRLEGroup - Expands runes up to the end of the input (or the closing parenthesis of a group) and returns the remaining runes
+func decodeRLEGroup(runes []rune, inGroup bool) (string, []rune, error) {
+	var out strings.Builder
+	count := 0
+	for len(runes) > 0 {
+		r := runes[0]
+		runes = runes[1:]
+		switch {
+		case r >= '0' && r <= '9':
+			count = count*10 + int(r-'0')
+			if count > maxRLELength {
+				return "", nil, fmt.Errorf("count over %d", maxRLELength)
+			}
+		case r == '(':
+			group, rest, err := decodeRLEGroup(runes, true)
+			if err != nil {
+				return "", nil, err
+			}
+			if err := repeatRLE(&out, group, count); err != nil {
+				return "", nil, err
+			}
+			count = 0
+			runes = rest
+		case r == ')':
+			if !inGroup {
+				return "", nil, fmt.Errorf("unmatched %q", r)
+			}
+			if count != 0 {
+				return "", nil, fmt.Errorf("count %d is not followed by anything", count)
+			}
+			return out.String(), runes, nil
+		default:
+			if err := repeatRLE(&out, string(r), count); err != nil {
+				return "", nil, err
+			}
+			count = 0
+		}
+	}
+	if inGroup {
+		return "", nil, fmt.Errorf("unmatched %q", '(')
+	}
+	if count != 0 {
+		return "", nil, fmt.Errorf("count %d is not followed by anything", count)
+	}
+	return out.String(), runes, nil
+}
+
+// repeatRLE - Writes s count times (once if count is 0), unless out would grow over maxRLELength
+func repeatRLE(out *strings.Builder, s string, count int) error {
+	count = max(count, 1)
+	if len(s) > 0 && count > (maxRLELength-out.Len())/len(s) {
+		return fmt.Errorf("decoded length over %d", maxRLELength)
+	}
+	out.WriteString(strings.Repeat(s, count))
+	return nil
+}
+
+// LevelToRLE - Encodes a level in RLE notation ("|" separates rows, "-" is floor, trailing floor is dropped)
+func LevelToRLE(l Level) string {
+	rows := make([]string, l.Height)
+	for y := 0; y < l.Height; y++ {
+		row := strings.TrimRight(l.MapData[y*l.Width:(y+1)*l.Width], " ")
+		rows[y] = strings.ReplaceAll(row, " ", "-")
+	}
+	return EncodeRLE(strings.Join(rows, "|"))
+}
+
+// LevelFromRLE - Decodes a level from RLE notation (see LevelToRLE), ragged rows are padded with floor
+func LevelFromRLE(s string) (Level, error) {
+	decoded, err := DecodeRLE(strings.TrimSpace(s))
+	if err != nil {
+		return Level{}, err
+	}
+	rows := strings.Split(decoded, "|")
+	for i, row := range rows {
+		rows[i] = normaliseBoardRow(row)
+	}
+	l := newLevelFromRows(rows)
+	if err := l.Validate(); err != nil {
+		return Level{}, err
+	}
+	return l, nil
+}
+
+// EncodeMoves - Encodes moves as a run-length encoded LURD string (e.g. "3lR")
+func EncodeMoves(moves []Move) string {
+	return EncodeRLE(FormatMoves(moves))
+}
+
+// DecodeMoves - Decodes a LURD string, plain or run-length encoded (whitespace is ignored)
+func DecodeMoves(s string) ([]Move, error) {
+	s = strings.Join(strings.Fields(s), "")
+	decoded, err := DecodeRLE(s)
+	if err != nil {
+		return nil, err
+	}
+	moves := make([]Move, 0, len(decoded))
+	for _, r := range decoded {
+		mv, err := ParseMove(r)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, mv)
+	}
+	return moves, nil
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRLE(t *testing.T) {
+	assert.Equal(t, "3#2 $", EncodeRLE("###  $"))
+	assert.Equal(t, "", EncodeRLE(""))
+	assert.Equal(t, "12-#", EncodeRLE("------------#"))
+
+	s, err := DecodeRLE("3#2 $")
+	assert.NoError(t, err)
+	assert.Equal(t, "###  $", s)
+
+	s, err = DecodeRLE("12-#")
+	assert.NoError(t, err)
+	assert.Equal(t, "------------#", s)
+
+	s, err = DecodeRLE("2(l3R)u")
+	assert.NoError(t, err)
+	assert.Equal(t, "lRRRlRRRu", s)
+
+	_, err = DecodeRLE("3")
+	assert.Error(t, err)
+	_, err = DecodeRLE("(lu")
+	assert.Error(t, err)
+	_, err = DecodeRLE("lu)")
+	assert.Error(t, err)
+
+	// counts are ASCII digits, and neither they nor the decoded string grow without bound
+	s, err = DecodeRLE("٣l")
+	assert.NoError(t, err)
+	assert.Equal(t, "٣l", s)
+	_, err = DecodeMoves("٣l")
+	assert.Error(t, err)
+	_, err = DecodeRLE("9999999999999999l")
+	assert.EqualError(t, err, "count over 1048576")
+	_, err = DecodeRLE("1000(1000(2l))")
+	assert.EqualError(t, err, "decoded length over 1048576")
+	s, err = DecodeRLE("1024(1024l)")
+	assert.NoError(t, err)
+	assert.Equal(t, maxRLELength, len(s))
+}
+
+func TestLevelRLE(t *testing.T) {
+	l := Level{
+		Width:  8,
+		Height: 4,
+		MapData: "" +
+			"########" +
+			"#.$  $.#" +
+			"#*.@$  #" +
+			"########",
+	}
+	rle := LevelToRLE(l)
+	assert.Equal(t, "8#|#.$2-$.#|#*.@$2-#|8#", rle)
+
+	decoded, err := LevelFromRLE(rle)
+	assert.NoError(t, err)
+	assert.Equal(t, l.Width, decoded.Width)
+	assert.Equal(t, l.Height, decoded.Height)
+	assert.Equal(t, l.MapData, decoded.MapData)
+
+	// trailing floor is dropped on encode and padded back on decode
+	l = Level{Width: 6, Height: 3, MapData: "####  #@$.# ####  "}
+	assert.Equal(t, "4#|#@$.#|4#", LevelToRLE(l))
+	decoded, err = LevelFromRLE("4#|#@$.#|4#")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, decoded.Width)
+	assert.Equal(t, "#### #@$.#####", decoded.MapData[:14])
+
+	_, err = LevelFromRLE("4#|#@$#|4#")
+	assert.Error(t, err)
+}
+
+func TestMovesRLE(t *testing.T) {
+	moves := []Move{
+		{Dir: direction.L}, {Dir: direction.L}, {Dir: direction.L},
+		{Dir: direction.U, Push: true}, {Dir: direction.U, Push: true},
+		{Dir: direction.R}, {Dir: direction.D, Push: true},
+	}
+	assert.Equal(t, "3l2UrD", EncodeMoves(moves))
+
+	decoded, err := DecodeMoves("3l2UrD")
+	assert.NoError(t, err)
+	assert.Equal(t, moves, decoded)
+
+	decoded, err = DecodeMoves("lll UU\nrD")
+	assert.NoError(t, err)
+	assert.Equal(t, moves, decoded)
+	assert.Equal(t, []direction.Direction{direction.L, direction.L, direction.L, direction.U, direction.U, direction.R, direction.D}, Directions(decoded))
+
+	_, err = DecodeMoves("3x")
+	assert.Error(t, err)
+}
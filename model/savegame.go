@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// SaveGame - The progress kept between sessions, the board and undo chain are rebuilt by replaying the moves
+type SaveGame struct {
+	Levels string `json:"levels,omitempty"` // level pack path, empty for the built-in levels
+	Level  int    `json:"level"`
+	Moves  string `json:"moves"` // moves played on the level (run-length encoded LURD)
+}
+
+// DefaultSavePath - Returns the save file location under the user config directory
+func DefaultSavePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sokoban-go", "save.json"), nil
+}
+
+// NewSaveGame - Captures the current level and the moves played on it
+func (m *Model) NewSaveGame() *SaveGame {
+	return &SaveGame{
+		Levels: m.LM.GetPath(),
+		Level:  m.LM.GetCurrentLevelNumber(),
+		Moves:  EncodeMoves(m.GetMoves()),
+	}
+}
+
+// IsOf - Returns true if the game was saved playing the pack at levelsPath ("" for the built-in levels), whatever the directory either path is relative to
+func (s *SaveGame) IsOf(levelsPath string) bool {
+	if levelsPath == "" || s.Levels == "" {
+		return levelsPath == s.Levels
+	}
+	abs, err := filepath.Abs(levelsPath)
+	if err != nil {
+		return false
+	}
+	saved, err := filepath.Abs(s.Levels)
+	return err == nil && abs == saved
+}
+
+// LoadSaveGame - Reads a save file
+func LoadSaveGame(path string) (*SaveGame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := SaveGame{}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Write - Writes the save file (creating its directory if needed)
+func (s *SaveGame) Write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveGame(t *testing.T) {
+	m := NewModel()
+	m.LM.ProgressToNextLevel()
+	m.LM.ProgressToNextLevel()
+	m.LastMove = NewLastMove(1, 1, -1, -1, -1, -1, direction.L, m.LastMove)
+	m.LastMove = NewLastMove(0, 1, -1, -1, -1, -1, direction.L, m.LastMove)
+	m.LastMove = NewLastMove(0, 1, 0, 2, 0, 3, direction.D, m.LastMove)
+
+	save := m.NewSaveGame()
+	assert.Equal(t, &SaveGame{Level: 2, Moves: "2lD"}, save)
+
+	path := filepath.Join(t.TempDir(), "sokoban-go", "save.json")
+	assert.NoError(t, save.Write(path))
+	loaded, err := LoadSaveGame(path)
+	assert.NoError(t, err)
+	assert.Equal(t, save, loaded)
+
+	_, err = LoadSaveGame(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestSaveGameIsOf(t *testing.T) {
+	builtIn := &SaveGame{Level: 2}
+	assert.True(t, builtIn.IsOf(""))
+	assert.False(t, builtIn.IsOf("pack.xsb"))
+
+	abs, err := filepath.Abs("pack.xsb")
+	assert.NoError(t, err)
+	pack := &SaveGame{Levels: abs, Level: 2}
+	assert.True(t, pack.IsOf("pack.xsb"))
+	assert.True(t, pack.IsOf("./pack.xsb"))
+	assert.False(t, pack.IsOf(""))
+	assert.False(t, pack.IsOf("other.xsb"))
+	// saves of older versions kept the path as typed
+	assert.True(t, (&SaveGame{Levels: "pack.xsb"}).IsOf(abs))
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"context"
+)
+
+// progressInterval - Boards searched between two progress reports (and cancellation checks)
+const progressInterval = 256
+
+// SearchProgress - State of the background hint search, as shown by the view
+type SearchProgress struct {
+	Running  bool
+	Explored int        // boards (or solver states) explored
+	Bound    int        // best solution length found so far, for the solver the lower bound on the pushes, or the moves when minimising them (0 if none yet)
+	Expired  bool       // the search was stopped before it found anything: no hint available
+	Table    TableStats // transposition table of the board search
+}
+
+// search - Lets a board search be cancelled and report its progress, shared by every board it reaches (see CheckEveryBoxMoveFromPlayerContext)
+type search struct {
+	ctx      context.Context
+	root     *Board
+	from     Position
+	boards   *Table
+	progress func(explored, bound int)
+	steps    int
+	err      error
+}
+
+// stopped - Returns true once the search context is done, reporting the progress every progressInterval calls
+func (s *search) stopped() bool {
+	if s == nil {
+		return false
+	}
+	if s.err != nil {
+		return true
+	}
+	s.steps++
+	if s.steps%progressInterval != 0 {
+		return false
+	}
+	s.report()
+	s.err = s.ctx.Err()
+	return s.err != nil
+}
+
+// report - Gives the boards explored and the best length found so far to the progress callback, if any
+func (s *search) report() {
+	if s.progress == nil {
+		return
+	}
+	bound := 0
+	if best := s.root.BestPositions[s.from]; best != nil && best.BestLength < 999 {
+		bound = best.BestLength
+	}
+	s.progress(s.boards.Stats().Misses, bound)
+}
+
+// CheckEveryBoxMoveFromPlayerContext - CheckEveryBoxMoveFromPlayer that gives up when ctx is done, returning its error (the boards then hold partial results and shall be dropped). progress, if not nil, gets the boards explored and the best length found so far, once more when the search is over
+func (b *Board) CheckEveryBoxMoveFromPlayerContext(ctx context.Context, boards *Table, progress func(explored, bound int)) error {
+	s := &search{ctx: ctx, root: b, from: Position{X: b.Player.X, Y: b.Player.Y}, boards: boards, progress: progress}
+	b.search = s
+	b.CheckEveryBoxMoveFromPlayer(boards)
+	b.search = nil
+	if s.err == nil {
+		s.err = ctx.Err()
+	}
+	if s.err == nil {
+		s.report()
+	}
+	return s.err
+}
+
+// ResetHints - Clears the hints (best path, box arrows and best position) until a search sets them again
+func (b *Board) ResetHints() {
+	b.ResetPath()
+	b._ResetCanBoxMove()
+	b.BestPositions[Position{X: b.Player.X, Y: b.Player.Y}] = &BestPosition{BestLength: 1000, BestX: -1, BestY: -1}
+}
+
+// CopyHints - Copies the hints of a searched board holding the same boxes and player position (box indexes may differ)
+func (b *Board) CopyHints(from *Board) {
+	for i := range b.Cells {
+		b.Cells[i].IsPath = from.Cells[i].IsPath
+		b.Cells[i].PathDir = from.Cells[i].PathDir
+		if b.Cells[i].HasBox {
+			box := &b.Boxes[b.Cells[i].Box]
+			fromBox := &from.Boxes[from.Cells[i].Box]
+			box.IsDead = fromBox.IsDead
+			copy(box.CanMove, fromBox.CanMove)
+			copy(box.ShallNotMove, fromBox.ShallNotMove)
+		}
+	}
+	best := *from.GetBestPosition()
+	b.BestPositions[Position{X: b.Player.X, Y: b.Player.Y}] = &best
+}
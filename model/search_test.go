@@ -0,0 +1,40 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEveryBoxMoveFromPlayerContext(t *testing.T) {
+	lm := NewLevelManager(false)
+	lm.SetCurrentLevelNumber(2)
+	l := lm.GetCurrentLevel()
+
+	// a finished search reports its progress and leaves the same hints as CheckEveryBoxMoveFromPlayer
+	b := NewBoard(l.MapData, l.Width, l.Height)
+	boards := NewTable(0)
+	explored := 0
+	err := b.CheckEveryBoxMoveFromPlayerContext(context.Background(), boards, func(e, bound int) { explored = e })
+	assert.NoError(t, err)
+	assert.Greater(t, explored, 0)
+	want := NewBoard(l.MapData, l.Width, l.Height)
+	want.CheckEveryBoxMoveFromPlayer(NewTable(0))
+	assert.Equal(t, want.GetBestPosition(), b.GetBestPosition())
+
+	// a cancelled one gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b = NewBoard(l.MapData, l.Width, l.Height)
+	assert.ErrorIs(t, b.CheckEveryBoxMoveFromPlayerContext(ctx, NewTable(0), nil), context.Canceled)
+
+	// the hints copy over to a board holding the same boxes
+	hinted := b.Duplicate()
+	hinted.CopyHints(want)
+	assert.Equal(t, want.GetBestPosition(), hinted.GetBestPosition())
+	assert.Equal(t, want.Get(want.Player.X, want.Player.Y).PathDir, hinted.Get(hinted.Player.X, hinted.Player.Y).PathDir)
+	hinted.ResetHints()
+	assert.Equal(t, 1000, hinted.GetBestPosition().BestLength)
+	assert.Equal(t, 0, hinted.GetGoodBoxMoveCount())
+}
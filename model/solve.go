@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+)
+
+// maxSolutionMoves - Guards against following hints that never reach the goal
+const maxSolutionMoves = 100000
+
+// ErrUnsolvable - Returned when the solver finds no way to complete the level
+var ErrUnsolvable = errors.New("no solution found")
+
+// Solution - A full solution found by the built-in solver
+type Solution struct {
+	Moves    []Move
+	Pushes   int
+	Boards   int // boards explored (TableStats.Misses)
+	Meet     int // pushes before the forward and backward searches met (bidirectional solver only)
+	Duration time.Duration
+}
+
+// SolveLevel - Runs the built-in solver (the one behind the hints) and follows its best path until the level is complete, giving up with the context error once ctx is done.
+// Unlike the hints it only makes the pushes opening a PI-corral when there is one, solving bigger levels at the cost of longer walks
+func SolveLevel(ctx context.Context, l Level) (*Solution, error) {
+	start := time.Now()
+	b := NewBoard(l.MapData, l.Width, l.Height)
+	boards := NewTable(DefaultTableBytes)
+	boards.PICorrals = true
+	s := &Solution{Moves: []Move{}}
+
+	err := b.CheckEveryBoxMoveFromPlayerContext(ctx, boards, nil)
+	for err == nil && !b.IsComplete() {
+		dir := b.Get(b.Player.X, b.Player.Y).PathDir
+		if b.GetBestPosition().BestLength >= 999 || dir == direction.None || len(s.Moves) >= maxSolutionMoves {
+			s.Boards = boards.Stats().Misses
+			s.Duration = time.Since(start)
+			return s, ErrUnsolvable
+		}
+
+		dx, dy := getMoveDirection(dir)
+		x, y := b.Player.X-dx, b.Player.Y-dy
+		push := b.Get(x, y).HasBox
+		if push {
+			b = b.MoveBoxAndCheck(x, y, dir, boards)
+			s.Pushes++
+		} else {
+			b.Player.X = x
+			b.Player.Y = y
+		}
+		s.Moves = append(s.Moves, Move{Dir: dir, Push: push})
+		err = b.CheckEveryBoxMoveFromPlayerContext(ctx, boards, nil)
+	}
+
+	s.Boards = boards.Stats().Misses
+	s.Duration = time.Since(start)
+	return s, err
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveLevel(t *testing.T) {
+	lm := NewLevelManager(false)
+	lm.ProgressToNextLevel()
+	s, err := SolveLevel(context.Background(), *lm.GetCurrentLevel())
+	assert.NoError(t, err)
+	// the PI-corral pruning keeps the pushes, not the walks between them (the hints find 10 moves)
+	assert.Equal(t, 13, len(s.Moves))
+	assert.Equal(t, 6, s.Pushes)
+	assert.True(t, s.Boards > 0)
+
+	_, err = SolveLevel(context.Background(), Level{Width: 5, Height: 4, MapData: "#####" + "#@ .#" + "#$  #" + "#####"})
+	assert.Equal(t, ErrUnsolvable, err)
+}
+
+func TestSolveLevelContext(t *testing.T) {
+	lm := NewLevelManager(false)
+	lm.SetCurrentLevelNumber(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := SolveLevel(ctx, *lm.GetCurrentLevel())
+	assert.ErrorIs(t, err, context.Canceled)
+}
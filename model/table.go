@@ -0,0 +1,262 @@
+package model
+
+import (
+	"unsafe"
+)
+
+// DefaultTableBytes - Memory limit of the transposition table behind the hints (256 MB)
+const DefaultTableBytes = 256 << 20
+
+// stateBytes - Estimated size of a compact state, on top of its box bitset
+const stateBytes = 64
+
+// Table - Transposition table of the board search, keyed on GetKey. The boards being searched are kept in full,
+// once over its memory limit the oldest searched boards are evicted down to compact states (box bitset, player region and best length),
+// and past that the oldest compact states are dropped (they are searched again if reached).
+// The boards the search is inside of are never evicted, a deep search may go over the limit
+type Table struct {
+	MaxBytes  int  // memory limit (estimated), 0 for none
+	PICorrals bool // restrict the search to the pushes opening a PI-corral (see _FindPICorral): fewer boards, longer walks. Not to be changed once the table is filled
+
+	boards     map[uint64]*Board
+	states     map[uint64]*tableState
+	boardOrder keyQueue
+	stateOrder keyQueue
+	bytes      int
+	stats      TableStats
+}
+
+// TableStats - Transposition table statistics, as shown by the view
+type TableStats struct {
+	Hits      int // boards found in the table (in full or as a compact state)
+	Misses    int // boards searched from scratch
+	Evictions int // boards evicted down to compact states, and compact states dropped
+	Boards    int // boards kept in full
+	States    int // compact states
+	Bytes     int // estimated memory use
+}
+
+// tableState - What is left of an evicted board: enough to check it is the same state, and its best length from one position of the player region
+type tableState struct {
+	boxes  []uint64 // box bitset, by cell index
+	region int      // normalised player position (see GetPlayerRegion)
+	from   int      // cell index the best length was searched from
+	best   int
+}
+
+// keyQueue - Keys in insertion order, the oldest first (keys since removed from the table are skipped when popped)
+type keyQueue struct {
+	keys []uint64
+	head int
+}
+
+func (q *keyQueue) push(key uint64) {
+	q.keys = append(q.keys, key)
+}
+
+func (q *keyQueue) pop() (uint64, bool) {
+	if q.head == len(q.keys) {
+		return 0, false
+	}
+	key := q.keys[q.head]
+	q.head++
+	if q.head > 1024 && q.head*2 > len(q.keys) {
+		q.keys = append(q.keys[:0], q.keys[q.head:]...)
+		q.head = 0
+	}
+	return key, true
+}
+
+func (q *keyQueue) len() int {
+	return len(q.keys) - q.head
+}
+
+// NewTable - Creates a transposition table holding about maxBytes (0 for no limit)
+func NewTable(maxBytes int) *Table {
+	t := &Table{MaxBytes: maxBytes}
+	t.Reset()
+	return t
+}
+
+// Reset - Empties the table and its statistics (on a new level, or after a search gave up half way)
+func (t *Table) Reset() {
+	t.boards = make(map[uint64]*Board)
+	t.states = make(map[uint64]*tableState)
+	t.boardOrder = keyQueue{}
+	t.stateOrder = keyQueue{}
+	t.bytes = 0
+	t.stats = TableStats{}
+}
+
+// Len - Returns the number of boards in the table, in full or compact
+func (t *Table) Len() int {
+	return len(t.boards) + len(t.states)
+}
+
+// Stats - Returns the table statistics
+func (t *Table) Stats() TableStats {
+	s := t.stats
+	s.Boards = len(t.boards)
+	s.States = len(t.states)
+	s.Bytes = t.bytes
+	return s
+}
+
+// _Get - Returns the board b shall be searched as: the board in the table with the same key (hit) or b itself, added to the table (miss).
+// A board found as a compact state comes back to the table in full, with its best length from the player position already known
+func (t *Table) _Get(b *Board) *Board {
+	key := b.GetKey()
+	if board := t.boards[key]; board != nil {
+		if board._HoldsStateOf(b) {
+			t.stats.Hits++
+			return board
+		}
+		// another state with the same key: searched on its own, the table keeps the first one
+		t.stats.Misses++
+		b._ResetCanBoxMove()
+		return b
+	}
+
+	b._ResetCanBoxMove()
+	if s := t.states[key]; s != nil && s.matches(b) {
+		t.stats.Hits++
+		t._DropState(key, s)
+		pos := Position{X: b.Player.X, Y: b.Player.Y}
+		best := s.best
+		if best > 0 && best < 999 {
+			// walk to where it was searched from, then follow its best path
+			best += b.GetDist(pos, s.from%b.Width, s.from/b.Width)
+		}
+		b.BestPositions[pos] = &BestPosition{BestLength: best, BestX: -1, BestY: -1}
+	} else {
+		t.stats.Misses++
+	}
+	t._Put(key, b)
+	return b
+}
+
+// _Put - Adds a board in full, evicting older ones first if it would not fit
+func (t *Table) _Put(key uint64, b *Board) {
+	b.tableBytes = b._Bytes()
+	b.tableRegion = b.GetPlayerRegion()
+	t._MakeRoom(b.tableBytes)
+	t.boards[key] = b
+	t.boardOrder.push(key)
+	t.bytes += b.tableBytes
+}
+
+// _MakeRoom - Evicts the oldest boards that are not being searched (then the oldest compact states) until size more bytes fit
+func (t *Table) _MakeRoom(size int) {
+	if t.MaxBytes <= 0 {
+		return
+	}
+	// boards being searched go back in the queue, there are only as many as the search is deep
+	for tries := t.boardOrder.len(); t.bytes+size > t.MaxBytes && tries > 0; tries-- {
+		key, _ := t.boardOrder.pop()
+		b := t.boards[key]
+		if b == nil {
+			continue
+		}
+		if b.searching > 0 {
+			t.boardOrder.push(key)
+			continue
+		}
+		t._Evict(key, b)
+	}
+	for t.bytes+size > t.MaxBytes {
+		key, ok := t.stateOrder.pop()
+		if !ok {
+			return
+		}
+		if s := t.states[key]; s != nil {
+			t._DropState(key, s)
+			t.stats.Evictions++
+		}
+	}
+}
+
+// _Evict - Replaces a board by its compact state (if its search found anything) and frees it, boards still pointing to it let it go (see GetOldMoveBox)
+func (t *Table) _Evict(key uint64, b *Board) {
+	delete(t.boards, key)
+	t.bytes -= b.tableBytes
+	t.stats.Evictions++
+
+	var s *tableState
+	for pos, best := range b.BestPositions {
+		if best.BestLength < 1000 && (s == nil || best.BestLength < s.best) {
+			s = &tableState{from: (pos.Y * b.Width) + pos.X, best: best.BestLength}
+		}
+	}
+	if s != nil {
+		s.boxes = b._BoxBits()
+		s.region = b.GetPlayerRegion()
+		t.states[key] = s
+		t.stateOrder.push(key)
+		t.bytes += s.bytes()
+	}
+
+	b.Cells = nil
+	b.Boxes = nil
+	b.Dists = nil
+	b.BestPositions = nil
+	b.queue = nil
+	b.corral = nil
+}
+
+// _DropState - Removes a compact state
+func (t *Table) _DropState(key uint64, s *tableState) {
+	delete(t.states, key)
+	t.bytes -= s.bytes()
+}
+
+// matches - Returns true if the state holds the same boxes and player region as b (the key alone may collide)
+func (s *tableState) matches(b *Board) bool {
+	if s.region != b.GetPlayerRegion() {
+		return false
+	}
+	boxes := b._BoxBits()
+	for i := range boxes {
+		if boxes[i] != s.boxes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// _HoldsStateOf - Returns true if the board, in the table, holds the same boxes as other and the player region of other (the key alone may collide)
+func (b *Board) _HoldsStateOf(other *Board) bool {
+	if b.tableRegion != other.GetPlayerRegion() || len(b.Cells) != len(other.Cells) {
+		return false
+	}
+	for i := range b.Cells {
+		if b.Cells[i].HasBox != other.Cells[i].HasBox {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *tableState) bytes() int {
+	return stateBytes + 8*len(s.boxes)
+}
+
+// _BoxBits - Returns the box positions as a bitset of cell indexes
+func (b *Board) _BoxBits() []uint64 {
+	bits := make([]uint64, (len(b.Cells)+63)/64)
+	for _, box := range b.Boxes {
+		i := (box.Y * b.Width) + box.X
+		bits[i/64] |= 1 << (i % 64)
+	}
+	return bits
+}
+
+// _Bytes - Returns an estimate of the memory held by the board (cells, boxes, distances and best positions), as the table accounts it when added
+func (b *Board) _Bytes() int {
+	size := int(unsafe.Sizeof(Board{})) + len(b.Cells)*int(unsafe.Sizeof(Cell{}))
+	for _, box := range b.Boxes {
+		size += int(unsafe.Sizeof(Box{})) + 3*4 + 4*8 + 48*len(box.XYChecked)
+	}
+	size += len(b.Dists) * (48 + 8*len(b.Cells))
+	size += len(b.BestPositions) * (48 + int(unsafe.Sizeof(BestPosition{})))
+	return size
+}
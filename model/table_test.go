@@ -0,0 +1,68 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	mapData := "" +
+		"######" +
+		"#@$ .#" +
+		"#    #" +
+		"######"
+	boards := NewTable(0)
+	b := NewBoard(mapData, 6, 4).GetBoard(boards)
+	b.CheckEveryBoxMoveFromPlayer(boards)
+	assert.Equal(t, 2, b.GetBestPosition().BestLength)
+	stats := boards.Stats()
+	assert.Equal(t, 0, stats.Evictions)
+	assert.Equal(t, stats.Misses, stats.Boards)
+	assert.True(t, stats.Bytes > 0)
+
+	// evicted down to a compact state, the board comes back with its best length, a walk away from where it was searched
+	key := b.GetKey()
+	boards._Evict(key, b)
+	assert.Nil(t, b.Cells)
+	assert.Equal(t, 1, boards.Stats().States)
+	d := NewBoard(mapData, 6, 4)
+	d.Player.Y = 2
+	d = d.GetBoard(boards)
+	assert.Equal(t, 3, d.GetBestPosition().BestLength)
+	assert.Equal(t, 0, boards.Stats().States)
+	assert.Equal(t, stats.Hits+1, boards.Stats().Hits)
+
+	// a board of another state under the same key (a collision) is not taken for it, the table keeps the first one
+	boards = NewTable(0)
+	b = NewBoard(mapData, 6, 4).GetBoard(boards)
+	moved := NewBoard(mapData, 6, 4)
+	moved.PlaceBox(2, 1, 3, 1)
+	boards.boards[moved.GetKey()] = b
+	assert.Same(t, moved, boards._Get(moved))
+	assert.Equal(t, 2, boards.Stats().Misses)
+	assert.Same(t, b, boards.boards[moved.GetKey()])
+	corridor := "######" + "#@$ .#" + "######"
+	b = NewBoard(corridor, 6, 3).GetBoard(boards)
+	other := NewBoard(corridor, 6, 3)
+	other.Player.X = 3 // the same boxes, the player in another region
+	boards.boards[other.GetKey()] = b
+	assert.Same(t, other, boards._Get(other))
+
+	// a search kept under a memory limit evicts boards but still finds its way
+	lm := NewLevelManager(false)
+	lm.SetCurrentLevelNumber(2)
+	l := lm.GetCurrentLevel()
+	boards = NewTable(256 << 10)
+	b = NewBoard(l.MapData, l.Width, l.Height).GetBoard(boards)
+	assert.NoError(t, b.CheckEveryBoxMoveFromPlayerContext(context.Background(), boards, nil))
+	assert.Less(t, b.GetBestPosition().BestLength, 999)
+	stats = boards.Stats()
+	assert.True(t, stats.Evictions > 0)
+	assert.LessOrEqual(t, stats.Bytes, 256<<10)
+
+	boards.Reset()
+	assert.Equal(t, 0, boards.Len())
+	assert.Equal(t, TableStats{}, boards.Stats())
+}
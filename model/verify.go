@@ -0,0 +1,117 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+)
+
+// Reasons a move is illegal, see IllegalMoveError
+var (
+	ErrPlayerBlocked  = errors.New("player blocked (wall)")
+	ErrBoxBlockedWall = errors.New("box blocked (wall)")
+	ErrBoxBlockedBox  = errors.New("box blocked (box)")
+	ErrOffBoard       = errors.New("off the board")
+	ErrLevelComplete  = errors.New("level already complete")
+	ErrNoDirection    = errors.New("not a direction")
+)
+
+// IllegalMoveError - The first move Verify could not play
+type IllegalMoveError struct {
+	Index  int // of the move in the list (0 is the first one)
+	Dir    direction.Direction
+	Reason error // one of the Err* reasons above
+}
+
+func (e *IllegalMoveError) Error() string {
+	if e.Dir < direction.U || e.Dir >= direction.None {
+		return fmt.Sprintf("move %d: %v", e.Index+1, e.Reason)
+	}
+	return fmt.Sprintf("move %d (%v): %v", e.Index+1, e.Dir, e.Reason)
+}
+
+func (e *IllegalMoveError) Unwrap() error {
+	return e.Reason
+}
+
+// Verification - What Verify made of a list of moves
+type Verification struct {
+	Moves    []Move // the moves played, up to the first illegal one (Push tells which pushed a box)
+	Pushes   int
+	Complete bool // every box ended on a goal
+}
+
+// Verify - Replays moves from the start of the level with the game rules (see PlayMove).
+// It returns what was played, along with an IllegalMoveError at the first move breaking the rules
+func Verify(level Level, moves []direction.Direction) (*Verification, error) {
+	b := NewBoard(level.MapData, level.Width, level.Height)
+	v := &Verification{Moves: []Move{}, Complete: b.IsComplete()}
+	for i, dir := range moves {
+		mv, err := b.PlayMove(dir)
+		if err != nil {
+			return v, &IllegalMoveError{Index: i, Dir: dir, Reason: err}
+		}
+		if mv.Push {
+			v.Pushes++
+			v.Complete = b.IsComplete()
+		}
+		v.Moves = append(v.Moves, mv)
+	}
+	return v, nil
+}
+
+// PlayMove - Moves the player one step in dir with the game rules: a wall blocks the player, a box is pushed unless a wall or another box is behind it, nothing moves once the level is complete.
+// It returns the move played, or why it could not be (one of the Err* reasons above)
+func (b *Board) PlayMove(dir direction.Direction) (Move, error) {
+	if err := b._VerifyMove(dir); err != nil {
+		return Move{}, err
+	}
+	dx, dy := getMoveDirection(dir)
+	x, y := b.Player.X-dx, b.Player.Y-dy
+	push := b.IsPush(dir)
+	if push {
+		b.MoveBox(x, y, dir)
+	} else {
+		b.Player.X = x
+		b.Player.Y = y
+	}
+	return Move{Dir: dir, Push: push}, nil
+}
+
+// _VerifyMove - Returns why the player cannot move in dir, nil if it can
+func (b *Board) _VerifyMove(dir direction.Direction) error {
+	if dir < direction.U || dir >= direction.None {
+		return ErrNoDirection
+	}
+	if b.IsComplete() {
+		return ErrLevelComplete
+	}
+	dx, dy := getMoveDirection(dir)
+	x, y := b.Player.X-dx, b.Player.Y-dy
+	if !b._InBounds(x, y) {
+		return ErrOffBoard
+	}
+	if b.Get(x, y).TypeOf == CellTypeWall {
+		return ErrPlayerBlocked
+	}
+	if !b.Get(x, y).HasBox {
+		return nil
+	}
+	if !b._InBounds(x-dx, y-dy) {
+		return ErrOffBoard
+	}
+	next := b.Get(x-dx, y-dy)
+	if next.TypeOf == CellTypeWall {
+		return ErrBoxBlockedWall
+	}
+	if next.HasBox {
+		return ErrBoxBlockedBox
+	}
+	return nil
+}
+
+// _InBounds - Returns true if x,y is a cell of the board
+func (b *Board) _InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.Width && y < b.Height
+}
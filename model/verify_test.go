@@ -0,0 +1,63 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	level := Level{Width: 7, Height: 4, MapData: "" +
+		"#######" +
+		"#@ $ .#" +
+		"#  $ .#" +
+		"#######"}
+	u, d, l, r := direction.U, direction.D, direction.L, direction.R
+
+	// a solution
+	v, err := Verify(level, []direction.Direction{r, r, r, l, l, d, r, r})
+	assert.NoError(t, err)
+	assert.True(t, v.Complete)
+	assert.Equal(t, 4, v.Pushes)
+	assert.Equal(t, "rRRlldRR", FormatMoves(v.Moves))
+
+	// legal moves that do not solve the level
+	v, err = Verify(level, []direction.Direction{r, d})
+	assert.NoError(t, err)
+	assert.False(t, v.Complete)
+	assert.Equal(t, 2, len(v.Moves))
+	assert.Equal(t, 0, v.Pushes)
+
+	// the first illegal move, and what was played before it
+	for _, c := range []struct {
+		moves  []direction.Direction
+		index  int
+		reason error
+	}{
+		{[]direction.Direction{u}, 0, ErrPlayerBlocked},
+		{[]direction.Direction{r, r, r, r}, 3, ErrBoxBlockedWall},
+		{[]direction.Direction{r, r, r, l, l, d, r, r, l}, 8, ErrLevelComplete},
+		{[]direction.Direction{r, direction.None}, 1, ErrNoDirection},
+	} {
+		v, err = Verify(level, c.moves)
+		var illegal *IllegalMoveError
+		assert.True(t, errors.As(err, &illegal))
+		assert.Equal(t, c.index, illegal.Index)
+		assert.ErrorIs(t, err, c.reason)
+		assert.Equal(t, c.index, len(v.Moves))
+	}
+	_, err = Verify(level, []direction.Direction{r, r, r, r})
+	assert.EqualError(t, err, "move 4 (R): box blocked (wall)")
+	_, err = Verify(level, []direction.Direction{direction.None})
+	assert.EqualError(t, err, "move 1: not a direction")
+
+	_, err = Verify(Level{Width: 6, Height: 1, MapData: "#@$$.."}, []direction.Direction{r})
+	assert.ErrorIs(t, err, ErrBoxBlockedBox)
+
+	// a level that is not closed by walls
+	v, err = Verify(Level{Width: 3, Height: 1, MapData: "@$."}, []direction.Direction{l})
+	assert.ErrorIs(t, err, ErrOffBoard)
+	assert.False(t, v.Complete)
+}
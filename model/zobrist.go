@@ -0,0 +1,39 @@
+package model
+
+// zobrist kinds, each cell index gets one random value per kind
+const (
+	zobristBox uint64 = iota
+	zobristPlayer
+)
+
+// zobrist - Returns the random value of a cell index for a kind (splitmix64 of both, so no table is needed)
+func zobrist(i int, kind uint64) uint64 {
+	z := uint64(i)*2 + kind + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// _HashBoxes - Computes the box hash from scratch
+func (b *Board) _HashBoxes() {
+	b.BoxHash = 0
+	for _, box := range b.Boxes {
+		b.BoxHash ^= zobrist((box.Y*b.Width)+box.X, zobristBox)
+	}
+}
+
+// GetPlayerRegion - Returns the cell index of the top-left most cell the player can walk to (the normalised player position)
+func (b *Board) GetPlayerRegion() int {
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	for i, dist := range b.Dists[Position{X: b.Player.X, Y: b.Player.Y}] {
+		if dist != noDist {
+			return i
+		}
+	}
+	return (b.Player.Y * b.Width) + b.Player.X
+}
+
+// GetKey - Returns the Zobrist hash of the board state: box positions plus the player region, boards only differing by where the player stands in the same region share a key
+func (b *Board) GetKey() uint64 {
+	return b.BoxHash ^ zobrist(b.GetPlayerRegion(), zobristPlayer)
+}
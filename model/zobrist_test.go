@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKey(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#@ $ .#" +
+		"#   $.#" +
+		"#######"
+	b := NewBoard(mapData, 7, 4)
+
+	// the player region is normalised to its top-left most cell
+	assert.Equal(t, 8, b.GetPlayerRegion())
+	key := b.GetKey()
+	d := b.Duplicate()
+	d.Player.X = 2
+	d.Player.Y = 2
+	assert.Equal(t, key, d.GetKey())
+
+	// the incremental box hash matches one computed from scratch
+	d = b.Duplicate()
+	d.Player.X = 2
+	d.MoveBox(3, 1, direction.R)
+	hash := d.BoxHash
+	d._HashBoxes()
+	assert.Equal(t, hash, d.BoxHash)
+	assert.NotEqual(t, key, d.GetKey())
+
+	// pushing the box back restores the original key
+	d.MoveBox(4, 1, direction.L)
+	d.Player.X = 1
+	assert.Equal(t, key, d.GetKey())
+
+	// same boxes but a different player region
+	mapData = "" +
+		"#######" +
+		"#@ $ .#" +
+		"#######"
+	b = NewBoard(mapData, 7, 3)
+	d = b.Duplicate()
+	d.Player.X = 5
+	assert.NotEqual(t, b.GetKey(), d.GetKey())
+}
+
+func TestGetBoardCollapsesRegion(t *testing.T) {
+	mapData := "" +
+		"######" +
+		"#@  .#" +
+		"# $  #" +
+		"######"
+	boards := NewTable(0)
+	b := NewBoard(mapData, 6, 4).GetBoard(boards)
+
+	d := b.Duplicate()
+	d.Player.X = 3
+	assert.Same(t, b, d.GetBoard(boards))
+	assert.Equal(t, 1, boards.Len())
+	assert.Equal(t, 3, b.Player.X)
+}
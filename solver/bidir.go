@@ -0,0 +1,213 @@
+package solver
+
+import (
+	"context"
+	"sort"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// bnode - A state of the bidirectional search, reached by pushes from the start (forward) or by pulls from the goals (backward).
+// box and dir give the push between the state and its parent: forward, box was pushed in dir to get here; backward, pushing box in dir from here gives back the parent
+type bnode struct {
+	boxes  []int // sorted box cells
+	player int
+	g      int // pushes (or pulls) from the start (or the goals)
+	parent *bnode
+	box    int
+	dir    direction.Direction
+}
+
+// frontier - One direction of the bidirectional search: every state it reached (by key) and the ones of the last layer
+type frontier struct {
+	backward bool
+	seen     map[string]*bnode
+	layer    []*bnode
+	depth    int
+}
+
+// add - Records a newly reached state, returns false if it was reached before
+func (f *frontier) add(n *bnode, key string) bool {
+	if _, ok := f.seen[key]; ok {
+		return false
+	}
+	f.seen[key] = n
+	f.layer = append(f.layer, n)
+	return true
+}
+
+// solveBidirectional - Breadth first searches forward with pushes from the start and backward with pulls from the boxes on goals, a layer of the smaller side at a time.
+// The first layer reaching a state (boxes and player region) the other side reached gives the fewest pushes, the solution is its forward path followed by the backward one pushed back
+func (l *level) solveBidirectional(ctx context.Context, start *node, opts Options) (*Result, error) {
+	l.findPullable(start.boxes)
+	fwd := &frontier{seen: make(map[string]*bnode)}
+	bwd := &frontier{seen: make(map[string]*bnode), backward: true}
+
+	// the player may finish in any area the boxes on goals leave
+	goals := append([]int{}, l.goals...)
+	sort.Ints(goals)
+	occ := l.occupancy(goals)
+	covered := make([]bool, len(l.walls))
+	for cell := range l.walls {
+		if !l.isFloor(cell) || occ[cell] || covered[cell] {
+			continue
+		}
+		dist, region := l.reach(occ, cell)
+		for i, d := range dist {
+			covered[i] = covered[i] || d != unreachable
+		}
+		bwd.add(&bnode{boxes: goals, player: cell}, stateKey(goals, region))
+	}
+
+	first := &bnode{boxes: start.boxes, player: start.player}
+	_, region := l.reach(l.occupancy(first.boxes), first.player)
+	key := stateKey(first.boxes, region)
+	fwd.add(first, key)
+	if met := bwd.seen[key]; met != nil {
+		return l.meet(first, met, 0), nil
+	}
+
+	explored := 0
+	for len(fwd.layer) > 0 && len(bwd.layer) > 0 {
+		side, other := fwd, bwd
+		if len(bwd.layer) < len(fwd.layer) {
+			side, other = bwd, fwd
+		}
+		layer := side.layer
+		side.layer = nil
+		var best, bestOther *bnode
+		for _, n := range layer {
+			if explored%progressInterval == 0 {
+				if opts.Progress != nil {
+					opts.Progress(explored, fwd.depth+bwd.depth)
+				}
+				if err := ctx.Err(); err != nil {
+					return &Result{Explored: explored}, err
+				}
+			}
+			explored++
+			if opts.MaxStates > 0 && explored >= opts.MaxStates {
+				return &Result{Explored: explored}, ErrLimit
+			}
+			for _, child := range l.bchildren(n, side.backward) {
+				_, region := l.reach(l.occupancy(child.boxes), child.player)
+				key := stateKey(child.boxes, region)
+				if !side.add(child, key) {
+					continue
+				}
+				if met := other.seen[key]; met != nil && (best == nil || met.g < bestOther.g) {
+					best, bestOther = child, met
+				}
+			}
+		}
+		side.depth++
+		if best != nil {
+			if side.backward {
+				best, bestOther = bestOther, best
+			}
+			return l.meet(best, bestOther, explored), nil
+		}
+	}
+	return &Result{Explored: explored}, ErrNoSolution
+}
+
+// bchildren - Returns the states reached by every push (forward) or pull (backward, see pulls) the player can make from n
+func (l *level) bchildren(n *bnode, backward bool) []*bnode {
+	if backward {
+		return l.pulls(n)
+	}
+	occ := l.occupancy(n.boxes)
+	dist, _ := l.reach(occ, n.player)
+	children := []*bnode{}
+	for _, box := range n.boxes {
+		for _, dir := range dirs {
+			from := l.step(box, opposite(dir))
+			to := l.step(box, dir)
+			if from < 0 || dist[from] == unreachable || !l.isFloor(to) || occ[to] || l.dead[to] {
+				continue
+			}
+			children = append(children, &bnode{boxes: movedBox(n.boxes, box, to), player: box, g: n.g + 1, parent: n, box: box, dir: dir})
+		}
+	}
+	return children
+}
+
+// pulls - Returns the states reached by every pull the board gives for n (see model.Board.GetPulls), but the ones leaving a box where no push from the start brings it
+func (l *level) pulls(n *bnode) []*bnode {
+	children := []*bnode{}
+	for _, p := range l.boardOf(n).GetPulls() {
+		// the box goes back against the push, the player steps back one more cell
+		box := p.Y*l.width + p.X
+		to := l.step(box, opposite(p.Dir))
+		if !l.pullable[to] {
+			continue
+		}
+		children = append(children, &bnode{boxes: movedBox(n.boxes, box, to), player: l.step(to, opposite(p.Dir)), g: n.g + 1, parent: n, box: to, dir: p.Dir})
+	}
+	return children
+}
+
+// boardOf - Returns a copy of the board solved holding the boxes and player of n
+func (l *level) boardOf(n *bnode) *model.Board {
+	b := l.board.Duplicate()
+	occ := l.occupancy(n.boxes)
+	empty := []int{}
+	for _, box := range n.boxes {
+		if !b.Cells[box].HasBox {
+			empty = append(empty, box)
+		}
+	}
+	for i := range b.Boxes {
+		x, y := b.Boxes[i].X, b.Boxes[i].Y
+		if !occ[y*l.width+x] {
+			b.PlaceBox(x, y, empty[0]%l.width, empty[0]/l.width)
+			empty = empty[1:]
+		}
+	}
+	b.Player.X, b.Player.Y = n.player%l.width, n.player/l.width
+	return b
+}
+
+// findPullable - Marks the cells a box can be pushed to from one of the start cells (on an empty board), the backward search never pulls a box anywhere else
+func (l *level) findPullable(boxes []int) {
+	l.pullable = make([]bool, len(l.walls))
+	queue := append([]int{}, boxes...)
+	for _, box := range boxes {
+		l.pullable[box] = true
+	}
+	for head := 0; head < len(queue); head++ {
+		box := queue[head]
+		for _, dir := range dirs {
+			from := l.step(box, opposite(dir))
+			to := l.step(box, dir)
+			if l.isFloor(from) && l.isFloor(to) && !l.pullable[to] {
+				l.pullable[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+}
+
+// meet - Builds the solution through the state both searches reached: the forward pushes up to it, then the pulls of the backward search played as pushes
+func (l *level) meet(forward, backward *bnode, explored int) *Result {
+	pushes := []push{}
+	for n := forward; n.parent != nil; n = n.parent {
+		pushes = append(pushes, push{box: n.box, dir: n.dir})
+	}
+	for i, j := 0, len(pushes)-1; i < j; i, j = i+1, j-1 {
+		pushes[i], pushes[j] = pushes[j], pushes[i]
+	}
+	meet := len(pushes)
+	for n := backward; n.parent != nil; n = n.parent {
+		pushes = append(pushes, push{box: n.box, dir: n.dir})
+	}
+
+	start := forward
+	for start.parent != nil {
+		start = start.parent
+	}
+	r := l.replay(start.boxes, start.player, pushes, explored)
+	r.Meet = meet
+	return r
+}
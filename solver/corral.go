@@ -0,0 +1,32 @@
+package solver
+
+import "github.com/TheInvader360/sokoban-go/model"
+
+// corralState - A search state as model.FindPICorral sees it: the boxes of occ and the player reach of dist
+type corralState struct {
+	l    *level
+	occ  []bool
+	dist []int
+}
+
+func (c corralState) Wall(i int) bool      { return c.l.walls[i] }
+func (c corralState) Goal(i int) bool      { return c.l.isGoal[i] }
+func (c corralState) Box(i int) bool       { return c.occ[i] }
+func (c corralState) Reachable(i int) bool { return c.dist[i] != unreachable }
+func (c corralState) Dead(i int) bool      { return c.l.dead[i] }
+
+// pushable - Returns the boxes worth pushing from a state: the fence of a PI-corral when there is one (it has to be opened first, see model.FindPICorral),
+// every box otherwise, none if a PI-corral can never be opened. Only for the fewest pushes: the walks around the corral may make other pushes cheaper in moves
+func (l *level) pushable(boxes []int, occ []bool, dist []int) []int {
+	if l.objective != model.ObjectivePushes {
+		return boxes
+	}
+	fence, closed := model.FindPICorral(corralState{l: l, occ: occ, dist: dist}, l.width, l.height)
+	if closed {
+		return nil
+	}
+	if fence != nil {
+		return fence
+	}
+	return boxes
+}
@@ -0,0 +1,69 @@
+package solver
+
+// heuristic - Returns a lower bound of the pushes left: the minimum cost matching of boxes to goals using push distances on an empty board (unreachable if some box cannot be matched)
+func (l *level) heuristic(boxes []int) int {
+	n, m := len(boxes), len(l.goals)
+	if n > m {
+		return unreachable
+	}
+
+	// Hungarian algorithm (rows are boxes, columns goals, 1-indexed)
+	u := make([]int, n+1)
+	v := make([]int, m+1)
+	match := make([]int, m+1)
+	way := make([]int, m+1)
+	minv := make([]int, m+1)
+	used := make([]bool, m+1)
+	for i := 1; i <= n; i++ {
+		match[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = 2 * unreachable
+			used[j] = false
+		}
+		for match[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := match[j0], 2*unreachable, 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				cur := l.pushDist[j-1][boxes[i0-1]] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			match[j0] = match[j1]
+			j0 = j1
+		}
+	}
+
+	cost := 0
+	for j := 1; j <= m; j++ {
+		if match[j] != 0 {
+			d := l.pushDist[j-1][boxes[match[j]-1]]
+			if d == unreachable {
+				return unreachable
+			}
+			cost += d
+		}
+	}
+	return cost
+}
@@ -0,0 +1,55 @@
+package solver
+
+import (
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// ApplyHint - Marks the board hints (best path, box arrows and best position) from a solution played from the current player position, nil moves mark the board as dead
+func ApplyHint(b *model.Board, moves []direction.Direction) {
+	b.ResetPath()
+	for i := range b.Boxes {
+		for dir := range b.Boxes[i].CanMove {
+			b.Boxes[i].CanMove[dir] = false
+			b.Boxes[i].ShallNotMove[dir] = true
+		}
+	}
+
+	pos := model.Position{X: b.Player.X, Y: b.Player.Y}
+	best := &model.BestPosition{BestLength: len(moves), BestX: -1, BestY: -1}
+	b.BestPositions[pos] = best
+	if moves == nil {
+		best.BestLength = 999
+		return
+	}
+
+	x, y := b.Player.X, b.Player.Y
+	for _, dir := range moves {
+		cell := b.Get(x, y)
+		cell.PathDir = dir
+		cell.IsPath = x != b.Player.X || y != b.Player.Y
+		x, y = next(x, y, dir)
+		if b.Get(x, y).HasBox {
+			box := &b.Boxes[b.Get(x, y).Box]
+			box.CanMove[dir] = true
+			box.ShallNotMove[dir] = false
+			best.BestX, best.BestY, best.BestDir = x, y, dir
+			return
+		}
+	}
+}
+
+// next - Returns the position one step away in the given direction
+func next(x, y int, dir direction.Direction) (int, int) {
+	switch dir {
+	case direction.U:
+		return x, y - 1
+	case direction.D:
+		return x, y + 1
+	case direction.L:
+		return x - 1, y
+	case direction.R:
+		return x + 1, y
+	}
+	return x, y
+}
@@ -0,0 +1,103 @@
+package solver
+
+import (
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// unreachable - Distance of the cells a box (or the player) can never get to, twice it still fits a 32-bit int (see heuristic)
+const unreachable = 1 << 29
+
+// dirs - The four push directions, in direction order
+var dirs = []direction.Direction{direction.U, direction.D, direction.L, direction.R}
+
+// level - The static part of a board (walls and goals) with its precomputed push distances
+type level struct {
+	width, height int
+	walls         []bool
+	isGoal        []bool
+	goals         []int
+	pushDist      [][]int      // pushDist[g][c] - pushes needed to bring a box from cell c to goal g on an empty board
+	dead          []bool       // cells from which a box can reach no goal (see model.Board.DeadCells)
+	pullable      []bool       // cells a box can be pushed to from its start cell, set by the bidirectional search (see findPullable)
+	board         *model.Board // the board solved, the backward search pulls on copies of it (see pulls)
+	objective     model.Objective
+}
+
+func newLevel(b *model.Board) *level {
+	l := &level{
+		width:  b.Width,
+		height: b.Height,
+		walls:  make([]bool, len(b.Cells)),
+		isGoal: make([]bool, len(b.Cells)),
+		dead:   b.DeadCells,
+		board:  b,
+	}
+	for i, cell := range b.Cells {
+		l.walls[i] = cell.TypeOf == model.CellTypeWall
+		if cell.TypeOf == model.CellTypeGoal {
+			l.isGoal[i] = true
+			l.goals = append(l.goals, i)
+		}
+	}
+	l.pushDist = make([][]int, len(l.goals))
+	for g, goal := range l.goals {
+		l.pushDist[g] = l.pullDistances(goal)
+	}
+	return l
+}
+
+// step - Returns the cell next to i in the given direction, -1 if off the board
+func (l *level) step(i int, dir direction.Direction) int {
+	x, y := i%l.width, i/l.width
+	switch dir {
+	case direction.U:
+		y--
+	case direction.D:
+		y++
+	case direction.L:
+		x--
+	case direction.R:
+		x++
+	}
+	if x < 0 || y < 0 || x >= l.width || y >= l.height {
+		return -1
+	}
+	return y*l.width + x
+}
+
+// isFloor - Returns true if i is on the board and not a wall
+func (l *level) isFloor(i int) bool {
+	return i >= 0 && !l.walls[i]
+}
+
+// pullDistances - Pulls a box backwards from the goal (ignoring other boxes) to count the pushes needed from every cell
+func (l *level) pullDistances(goal int) []int {
+	dist := make([]int, len(l.walls))
+	for i := range dist {
+		dist[i] = unreachable
+	}
+	dist[goal] = 0
+	queue := []int{goal}
+	for head := 0; head < len(queue); head++ {
+		box := queue[head]
+		for _, dir := range dirs {
+			// the box came from "from", pushed by a player standing one cell further
+			from := l.step(box, opposite(dir))
+			player := -1
+			if from >= 0 {
+				player = l.step(from, opposite(dir))
+			}
+			if l.isFloor(from) && l.isFloor(player) && dist[from] == unreachable {
+				dist[from] = dist[box] + 1
+				queue = append(queue, from)
+			}
+		}
+	}
+	return dist
+}
+
+// opposite - Returns the opposite direction
+func opposite(dir direction.Direction) direction.Direction {
+	return [...]direction.Direction{direction.D, direction.U, direction.R, direction.L}[dir]
+}
@@ -0,0 +1,155 @@
+package solver
+
+import (
+	"container/heap"
+	"context"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+)
+
+// batchSize - States taken off the open list at once by the parallel search. It does not depend on the number of workers, so neither does the solution
+const batchSize = 256
+
+// tableShards - Lock stripes of the parallel search closed table
+const tableShards = 64
+
+// claim - How a state was reached: objective cost, then batch and place in the batch, the lowest wins
+type claim struct {
+	cost         int64
+	batch, index int
+}
+
+func (c claim) less(o claim) bool {
+	if c.cost != o.cost {
+		return c.cost < o.cost
+	}
+	if c.batch != o.batch {
+		return c.batch < o.batch
+	}
+	return c.index < o.index
+}
+
+// closedTable - Closed list shared by the workers, sharded on the key hash so they seldom wait for each other
+type closedTable struct {
+	seed   maphash.Seed
+	shards [tableShards]struct {
+		mu     sync.Mutex
+		claims map[string]claim
+	}
+}
+
+func newClosedTable() *closedTable {
+	t := &closedTable{seed: maphash.MakeSeed()}
+	for i := range t.shards {
+		t.shards[i].claims = make(map[string]claim)
+	}
+	return t
+}
+
+// claim - Records c for the state unless it was claimed lower before (whatever the order claims come in, the lowest stays)
+func (t *closedTable) claim(key string, c claim) {
+	s := &t.shards[maphash.String(t.seed, key)%tableShards]
+	s.mu.Lock()
+	if old, ok := s.claims[key]; !ok || c.less(old) {
+		s.claims[key] = c
+	}
+	s.mu.Unlock()
+}
+
+// owner - Returns the lowest claim on the state
+func (t *closedTable) owner(key string) claim {
+	s := &t.shards[maphash.String(t.seed, key)%tableShards]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.claims[key]
+}
+
+// work - A state of the batch and what the workers found about it
+type work struct {
+	n        *node
+	occ      []bool
+	dist     []int
+	key      string
+	claim    claim
+	children []*node
+}
+
+// solveParallel - The A* search of Solve, expanding the open states of lowest f by batches on opts.Workers goroutines.
+// Each batch is claimed in the closed table then expanded in parallel, its results are taken in batch order: the solution is the same from one run to the next
+func (l *level) solveParallel(ctx context.Context, start *node, opts Options) (*Result, error) {
+	open := &nodeHeap{start}
+	closed := newClosedTable()
+	explored, seq, batch, nextReport := 0, 0, 0, 0
+	for open.Len() > 0 {
+		// every state of lowest f may lead to the optimal solution, whichever goes first
+		first := (*open)[0]
+		works := []*work{}
+		for open.Len() > 0 && len(works) < batchSize && (*open)[0].f == first.f {
+			works = append(works, &work{n: heap.Pop(open).(*node)})
+		}
+		batch++
+
+		if explored >= nextReport {
+			if opts.Progress != nil {
+				opts.Progress(explored, l.bound(first))
+			}
+			if err := ctx.Err(); err != nil {
+				return &Result{Explored: explored}, err
+			}
+			nextReport = explored - explored%progressInterval + progressInterval
+		}
+
+		parallel(opts.Workers, len(works), func(i int) {
+			w := works[i]
+			w.occ = l.occupancy(w.n.boxes)
+			w.dist, w.key = l.state(w.n, w.occ)
+			w.claim = claim{cost: l.cost(w.n.g, w.n.m), batch: batch, index: i}
+			closed.claim(w.key, w.claim)
+		})
+
+		expand := []*work{}
+		for _, w := range works {
+			if closed.owner(w.key) != w.claim {
+				continue
+			}
+			explored++
+			if l.solved(w.n.boxes) {
+				return l.solution(start, w.n, explored), nil
+			}
+			if opts.MaxStates > 0 && explored >= opts.MaxStates {
+				return &Result{Explored: explored}, ErrLimit
+			}
+			expand = append(expand, w)
+		}
+
+		parallel(opts.Workers, len(expand), func(i int) {
+			w := expand[i]
+			w.children = l.children(w.n, w.occ, w.dist)
+		})
+		for _, w := range expand {
+			for _, child := range w.children {
+				seq++
+				child.seq = seq
+				heap.Push(open, child)
+			}
+		}
+	}
+	return &Result{Explored: explored}, ErrNoSolution
+}
+
+// parallel - Calls do for every index below n from the given number of goroutines, returns once all calls are done
+func parallel(workers, n int, do func(i int)) {
+	var wg sync.WaitGroup
+	var next atomic.Int64
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1)) - 1; i < n; i = int(next.Add(1)) - 1 {
+				do(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,244 @@
+// Package solver finds push-optimal solutions with an A* search over push states
+package solver
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// ErrNoSolution - Returned when the level cannot be solved
+var ErrNoSolution = errors.New("no solution")
+
+// ErrLimit - Returned when the search gives up before finding a solution
+var ErrLimit = errors.New("search limit reached")
+
+// progressInterval - States expanded between two progress reports (and cancellation checks)
+const progressInterval = 1024
+
+// Options - Tunes the search
+type Options struct {
+	MaxStates     int                       // give up after expanding this many states (0 for no limit)
+	Progress      func(explored, bound int) // if set, called now and then with the states expanded and the lower bound on the pushes (the moves for ObjectiveMoves)
+	Workers       int                       // expand states on this many goroutines (0 or 1 for a single one), see solveParallel
+	Bidirectional bool                      // search forward from the start and backward from the goals until they meet, see solveBidirectional (fewest pushes only)
+	Objective     model.Objective           // what the solution has the fewest of (pushes by default)
+}
+
+// Result - A solution and how it was found
+type Result struct {
+	Moves    []direction.Direction // every player step, walks and pushes
+	Pushes   int
+	Explored int // states expanded
+	Meet     int // bidirectional search: pushes from the start to the state where both searches met, the rest was found pulling back from the goals
+}
+
+// node - A search state reached by pushing box pushFrom in direction pushDir from its parent
+type node struct {
+	boxes    []int // sorted box cells
+	player   int
+	g, h     int   // pushes so far, and at least left
+	m        int   // moves so far
+	f        int64 // the objective cost so far plus at least left (see level.cost), the open list order
+	seq      int
+	parent   *node
+	pushFrom int
+	pushDir  direction.Direction
+}
+
+// Solve - Searches the board (boxes and player as they stand) for a solution with the fewest pushes (or moves, see Options.Objective), giving up with the context error once ctx is done
+func Solve(ctx context.Context, b *model.Board, opts Options) (*Result, error) {
+	l := newLevel(b)
+	l.objective = opts.Objective
+	start := &node{player: b.Player.Y*b.Width + b.Player.X}
+	for _, box := range b.Boxes {
+		start.boxes = append(start.boxes, box.Y*b.Width+box.X)
+	}
+	sort.Ints(start.boxes)
+	start.h = l.heuristic(start.boxes)
+	if start.h == unreachable {
+		return &Result{}, ErrNoSolution
+	}
+	start.f = l.cost(start.h, start.h)
+	if opts.Bidirectional && opts.Objective == model.ObjectivePushes && len(start.boxes) == len(l.goals) {
+		return l.solveBidirectional(ctx, start, opts)
+	}
+	if opts.Workers > 1 {
+		return l.solveParallel(ctx, start, opts)
+	}
+
+	open := &nodeHeap{start}
+	closed := make(map[string]int64)
+	explored := 0
+	seq := 0
+	for open.Len() > 0 {
+		n := heap.Pop(open).(*node)
+		occ := l.occupancy(n.boxes)
+		dist, key := l.state(n, occ)
+		if g, ok := closed[key]; ok && g <= l.cost(n.g, n.m) {
+			continue
+		}
+		closed[key] = l.cost(n.g, n.m)
+
+		if explored%progressInterval == 0 {
+			if opts.Progress != nil {
+				opts.Progress(explored, l.bound(n))
+			}
+			if err := ctx.Err(); err != nil {
+				return &Result{Explored: explored}, err
+			}
+		}
+		explored++
+		if l.solved(n.boxes) {
+			return l.solution(start, n, explored), nil
+		}
+		if opts.MaxStates > 0 && explored >= opts.MaxStates {
+			return &Result{Explored: explored}, ErrLimit
+		}
+
+		for _, child := range l.children(n, occ, dist) {
+			seq++
+			child.seq = seq
+			heap.Push(open, child)
+		}
+	}
+	return &Result{Explored: explored}, ErrNoSolution
+}
+
+// children - Returns the states reached by every push the player can make from n (but the ones known to lead nowhere, see pushable)
+func (l *level) children(n *node, occ []bool, dist []int) []*node {
+	children := []*node{}
+	for _, box := range l.pushable(n.boxes, occ, dist) {
+		for _, dir := range dirs {
+			from := l.step(box, opposite(dir))
+			to := l.step(box, dir)
+			if from < 0 || dist[from] == unreachable || !l.isFloor(to) || occ[to] || l.dead[to] {
+				continue
+			}
+			boxes := movedBox(n.boxes, box, to)
+			h := l.heuristic(boxes)
+			if h == unreachable {
+				continue
+			}
+			m := n.m + dist[from] + 1
+			children = append(children, &node{boxes: boxes, player: box, g: n.g + 1, h: h, m: m, f: l.cost(n.g+1+h, m+h), parent: n, pushFrom: box, pushDir: dir})
+		}
+	}
+	return children
+}
+
+// cost - Returns what the objective counts for the given pushes and moves (both packed in one number when pushes come first, 64 bits whatever the size of int)
+func (l *level) cost(pushes, moves int) int64 {
+	switch l.objective {
+	case model.ObjectiveMoves:
+		return int64(moves)
+	case model.ObjectivePushesMoves:
+		return int64(pushes)<<32 | int64(moves)
+	}
+	return int64(pushes)
+}
+
+// bound - Returns the lower bound on the solution reported as progress: the moves when only they count, else the pushes
+func (l *level) bound(n *node) int {
+	if l.objective == model.ObjectiveMoves {
+		return n.m + n.h
+	}
+	return n.g + n.h
+}
+
+// state - Returns the walking distances of the player in n and the key of n: the boxes and the normalised player position, or the player cell itself when moves count
+func (l *level) state(n *node, occ []bool) ([]int, string) {
+	dist, region := l.reach(occ, n.player)
+	if l.objective != model.ObjectivePushes {
+		region = n.player
+	}
+	return dist, stateKey(n.boxes, region)
+}
+
+// push - A box cell and the direction it is pushed in
+type push struct {
+	box int
+	dir direction.Direction
+}
+
+// solution - Replays the pushes leading to the goal node, adding the walks in between
+func (l *level) solution(start, goal *node, explored int) *Result {
+	pushes := []push{}
+	for n := goal; n != start; n = n.parent {
+		pushes = append(pushes, push{box: n.pushFrom, dir: n.pushDir})
+	}
+	for i, j := 0, len(pushes)-1; i < j; i, j = i+1, j-1 {
+		pushes[i], pushes[j] = pushes[j], pushes[i]
+	}
+	return l.replay(start.boxes, start.player, pushes, explored)
+}
+
+// replay - Plays the pushes from the given boxes and player, adding the walks in between
+func (l *level) replay(boxes []int, player int, pushes []push, explored int) *Result {
+	r := &Result{Moves: []direction.Direction{}, Pushes: len(pushes), Explored: explored}
+	for _, p := range pushes {
+		r.Moves = append(r.Moves, l.path(l.occupancy(boxes), player, l.step(p.box, opposite(p.dir)))...)
+		r.Moves = append(r.Moves, p.dir)
+		boxes = movedBox(boxes, p.box, l.step(p.box, p.dir))
+		player = p.box
+	}
+	return r
+}
+
+// nodeHeap - Open list ordered by f, then deepest first, then insertion order
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int { return len(h) }
+
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].f != h[j].f {
+		return h[i].f < h[j].f
+	}
+	if h[i].h != h[j].h {
+		return h[i].h < h[j].h
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *nodeHeap) Push(x any) { *h = append(*h, x.(*node)) }
+
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// SolveLevel - Solves a level from its start and returns the solution the way model.SolveLevel does (Boards counts the states explored)
+func SolveLevel(ctx context.Context, l model.Level, opts Options) (*model.Solution, error) {
+	start := time.Now()
+	b := model.NewBoard(l.MapData, l.Width, l.Height)
+	r, err := Solve(ctx, b, opts)
+	s := &model.Solution{Moves: []model.Move{}, Pushes: r.Pushes, Boards: r.Explored, Meet: r.Meet}
+
+	// replay the steps to tell walks from pushes
+	occ := make([]bool, len(b.Cells))
+	for _, box := range b.Boxes {
+		occ[box.Y*b.Width+box.X] = true
+	}
+	lv := &level{width: b.Width, height: b.Height}
+	player := b.Player.Y*b.Width + b.Player.X
+	for _, dir := range r.Moves {
+		player = lv.step(player, dir)
+		push := occ[player]
+		if push {
+			occ[player] = false
+			occ[lv.step(player, dir)] = true
+		}
+		s.Moves = append(s.Moves, model.Move{Dir: dir, Push: push})
+	}
+	s.Duration = time.Since(start)
+	return s, err
+}
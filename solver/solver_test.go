@@ -0,0 +1,345 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// play - Replays the moves on the board, returns the number of pushes (-1 if a move is blocked)
+func play(b *model.Board, moves []direction.Direction) int {
+	pushes := 0
+	for _, dir := range moves {
+		x, y := next(b.Player.X, b.Player.Y, dir)
+		if b.Get(x, y).TypeOf == model.CellTypeWall {
+			return -1
+		}
+		if b.Get(x, y).HasBox {
+			toX, toY := next(x, y, dir)
+			if b.Get(toX, toY).TypeOf == model.CellTypeWall || b.Get(toX, toY).HasBox {
+				return -1
+			}
+			b.PlaceBox(x, y, toX, toY)
+			pushes++
+		}
+		b.Player.X, b.Player.Y = x, y
+	}
+	return pushes
+}
+
+func TestSolve(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#@ $ .#" +
+		"#######"
+	r, err := Solve(context.Background(), model.NewBoard(mapData, 7, 3), Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []direction.Direction{direction.R, direction.R, direction.R}, r.Moves)
+	assert.Equal(t, 2, r.Pushes)
+
+	// already solved
+	r, err = Solve(context.Background(), model.NewBoard("#@*#", 4, 1), Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []direction.Direction{}, r.Moves)
+	assert.Equal(t, 0, r.Pushes)
+
+	// box in a corner
+	mapData = "" +
+		"#####" +
+		"#@ .#" +
+		"#$  #" +
+		"#####"
+	_, err = Solve(context.Background(), model.NewBoard(mapData, 5, 4), Options{})
+	assert.ErrorIs(t, err, ErrNoSolution)
+
+	// boxes blocking each other
+	mapData = "" +
+		"######" +
+		"#.$$.#" +
+		"# @  #" +
+		"######"
+	_, err = Solve(context.Background(), model.NewBoard(mapData, 6, 4), Options{})
+	assert.ErrorIs(t, err, ErrNoSolution)
+}
+
+func TestSolveLimit(t *testing.T) {
+	lm := model.NewLevelManager(false)
+	lm.SetCurrentLevelNumber(8)
+	l := lm.GetCurrentLevel()
+	r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{MaxStates: 10})
+	assert.ErrorIs(t, err, ErrLimit)
+	assert.Equal(t, 10, r.Explored)
+}
+
+func TestSolveBuiltinLevels(t *testing.T) {
+	// fewest pushes of each built-in level
+	pushes := []int{6, 30, 13, 11, 13, 7, 16, 15, 17, 25}
+	lm := model.NewLevelManager(false)
+	for n := 1; n <= lm.GetFinalLevelNumber(); n++ {
+		lm.SetCurrentLevelNumber(n)
+		l := lm.GetCurrentLevel()
+		r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{})
+		if !assert.NoError(t, err, "level %d", n) {
+			continue
+		}
+		assert.Equal(t, pushes[n-1], r.Pushes, "level %d", n)
+
+		b := model.NewBoard(l.MapData, l.Width, l.Height)
+		assert.Equal(t, r.Pushes, play(b, r.Moves), "level %d", n)
+		assert.True(t, b.IsComplete(), "level %d", n)
+	}
+}
+
+func TestSolveLevel(t *testing.T) {
+	l := model.Level{Width: 7, Height: 3, MapData: "########@ $ .########"}
+	s, err := SolveLevel(context.Background(), l, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "rRR", model.FormatMoves(s.Moves))
+	assert.Equal(t, 2, s.Pushes)
+}
+
+func TestHeuristic(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#@ $ .#" +
+		"#  $ .#" +
+		"#######"
+	b := model.NewBoard(mapData, 7, 4)
+	l := newLevel(b)
+	assert.Equal(t, 4, l.heuristic([]int{10, 17}))
+	assert.Equal(t, 0, l.heuristic([]int{12, 19}))
+	assert.True(t, l.dead[8])
+	assert.False(t, l.dead[9])
+	assert.Equal(t, unreachable, l.heuristic([]int{8, 17}))
+}
+
+func TestPushable(t *testing.T) {
+	// the box at 4,3 fences a room the player can only open by pushing it in
+	mapData := "" +
+		"#########" +
+		"#   #   #" +
+		"# $ #   #" +
+		"#@  $ . #" +
+		"#.  #   #" +
+		"#########"
+	b := model.NewBoard(mapData, 9, 6)
+	l := newLevel(b)
+	boxes := []int{2*9 + 2, 3*9 + 4}
+	occ := l.occupancy(boxes)
+	dist, _ := l.reach(occ, 3*9+1)
+	assert.Equal(t, []int{3*9 + 4}, l.pushable(boxes, occ, dist))
+	r, err := Solve(context.Background(), b, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, r.Pushes)
+
+	// only the fewest pushes are restricted
+	l.objective = model.ObjectiveMoves
+	assert.Equal(t, boxes, l.pushable(boxes, occ, dist))
+
+	// the only push into the corral lands in a corner: it can never be opened
+	b = model.NewBoard("#######"+"#.@ $ #"+"#######", 7, 3)
+	l = newLevel(b)
+	boxes = []int{7 + 4}
+	occ = l.occupancy(boxes)
+	dist, _ = l.reach(occ, 7+2)
+	assert.Empty(t, l.pushable(boxes, occ, dist))
+}
+
+func TestApplyHint(t *testing.T) {
+	mapData := "" +
+		"#######" +
+		"#  $ .#" +
+		"#@    #" +
+		"#######"
+	b := model.NewBoard(mapData, 7, 4)
+	b.CheckEveryFreeSpace(b.Player.X, b.Player.Y)
+	r, err := Solve(context.Background(), b, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []direction.Direction{direction.U, direction.R, direction.R, direction.R}, r.Moves)
+
+	ApplyHint(b, r.Moves)
+	assert.Equal(t, direction.U, b.Get(1, 2).PathDir)
+	assert.False(t, b.Get(1, 2).IsPath)
+	assert.Equal(t, direction.R, b.Get(1, 1).PathDir)
+	assert.True(t, b.Get(1, 1).IsPath)
+	assert.True(t, b.Get(2, 1).IsPath)
+	assert.False(t, b.Get(4, 1).IsPath)
+	assert.True(t, b.Boxes[0].CanMove[direction.R])
+	assert.False(t, b.Boxes[0].ShallNotMove[direction.R])
+	assert.False(t, b.Boxes[0].CanMove[direction.L])
+	assert.Equal(t, &model.BestPosition{BestDir: direction.R, BestX: 3, BestY: 1, BestLength: 4}, b.GetBestPosition())
+
+	ApplyHint(b, nil)
+	assert.Equal(t, direction.None, b.Get(1, 2).PathDir)
+	assert.False(t, b.Boxes[0].CanMove[direction.R])
+	assert.Equal(t, 999, b.GetBestPosition().BestLength)
+}
+
+func TestSolveContext(t *testing.T) {
+	lm := model.NewLevelManager(false)
+	lm.SetCurrentLevelNumber(8)
+	l := lm.GetCurrentLevel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Solve(ctx, model.NewBoard(l.MapData, l.Width, l.Height), Options{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// progress is reported from the start, the bound never decreases
+	bounds := []int{}
+	_, err = Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Progress: func(explored, bound int) {
+		bounds = append(bounds, bound)
+	}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, bounds)
+	assert.IsNonDecreasing(t, bounds)
+}
+
+func TestSolveParallel(t *testing.T) {
+	pushes := []int{6, 30, 13, 11, 13, 7, 16, 15, 17, 25}
+	lm := model.NewLevelManager(false)
+	for n := 1; n <= lm.GetFinalLevelNumber(); n++ {
+		lm.SetCurrentLevelNumber(n)
+		l := lm.GetCurrentLevel()
+		r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Workers: 4})
+		if !assert.NoError(t, err, "level %d", n) {
+			continue
+		}
+		assert.Equal(t, pushes[n-1], r.Pushes, "level %d", n)
+		b := model.NewBoard(l.MapData, l.Width, l.Height)
+		assert.Equal(t, r.Pushes, play(b, r.Moves), "level %d", n)
+		assert.True(t, b.IsComplete(), "level %d", n)
+
+		// the same solution whatever the number of workers
+		other, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Workers: 2 + n%7})
+		assert.NoError(t, err, "level %d", n)
+		assert.Equal(t, r, other, "level %d", n)
+	}
+
+	lm.SetCurrentLevelNumber(8)
+	l := lm.GetCurrentLevel()
+	r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Workers: 4, MaxStates: 10})
+	assert.ErrorIs(t, err, ErrLimit)
+	assert.Equal(t, 10, r.Explored)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Solve(ctx, model.NewBoard(l.MapData, l.Width, l.Height), Options{Workers: 4})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = Solve(context.Background(), model.NewBoard("#####"+"#@ .#"+"#$  #"+"#####", 5, 4), Options{Workers: 4})
+	assert.ErrorIs(t, err, ErrNoSolution)
+}
+
+func TestSolveBidirectional(t *testing.T) {
+	pushes := []int{6, 30, 13, 11, 13, 7, 16, 15, 17, 25}
+	lm := model.NewLevelManager(false)
+	for n := 1; n <= lm.GetFinalLevelNumber(); n++ {
+		lm.SetCurrentLevelNumber(n)
+		l := lm.GetCurrentLevel()
+		r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Bidirectional: true})
+		if !assert.NoError(t, err, "level %d", n) {
+			continue
+		}
+		assert.Equal(t, pushes[n-1], r.Pushes, "level %d", n)
+		assert.True(t, r.Meet >= 0 && r.Meet <= r.Pushes, "level %d", n)
+		b := model.NewBoard(l.MapData, l.Width, l.Height)
+		assert.Equal(t, r.Pushes, play(b, r.Moves), "level %d", n)
+		assert.True(t, b.IsComplete(), "level %d", n)
+	}
+
+	r, err := Solve(context.Background(), model.NewBoard("#@*#", 4, 1), Options{Bidirectional: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []direction.Direction{}, r.Moves)
+
+	_, err = Solve(context.Background(), model.NewBoard("#####"+"#@ .#"+"#$  #"+"#####", 5, 4), Options{Bidirectional: true})
+	assert.ErrorIs(t, err, ErrNoSolution)
+
+	lm.SetCurrentLevelNumber(10)
+	l := lm.GetCurrentLevel()
+	r, err = Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Bidirectional: true, MaxStates: 10})
+	assert.ErrorIs(t, err, ErrLimit)
+	assert.Equal(t, 10, r.Explored)
+}
+
+func TestPulls(t *testing.T) {
+	// the backward search pulls the boxes the way the board does
+	mapData := "" +
+		"#######" +
+		"#  $  #" +
+		"# $@. #" +
+		"#   . #" +
+		"#######"
+	b := model.NewBoard(mapData, 7, 5)
+	l := newLevel(b)
+	l.findPullable(nil)
+	for i := range l.pullable {
+		l.pullable[i] = l.isFloor(i)
+	}
+	n := &bnode{player: b.Player.Y*b.Width + b.Player.X}
+	for _, box := range b.Boxes {
+		n.boxes = append(n.boxes, box.Y*b.Width+box.X)
+	}
+	pulls := b.GetPulls()
+	children := l.bchildren(n, true)
+	assert.Equal(t, len(pulls), len(children))
+	for i, pull := range pulls {
+		d := b.Duplicate()
+		d.PullBox(pull.X, pull.Y, pull.Dir)
+		assert.Equal(t, d.Player.Y*d.Width+d.Player.X, children[i].player)
+		assert.True(t, d.Get(children[i].box%b.Width, children[i].box/b.Width).HasBox)
+		assert.Equal(t, pull.Dir, children[i].dir)
+	}
+
+	// and on a copy of the board holding the boxes and player of the state
+	c := children[0]
+	d := l.boardOf(c)
+	for _, box := range c.boxes {
+		assert.True(t, d.Cells[box].HasBox)
+	}
+	assert.Equal(t, len(c.boxes), len(d.Boxes))
+	assert.Equal(t, c.player, d.Player.Y*d.Width+d.Player.X)
+	assert.False(t, b.Cells[c.box].HasBox)
+}
+
+func TestSolveObjectives(t *testing.T) {
+	// level 6: two more pushes save two moves
+	lm := model.NewLevelManager(false)
+	lm.SetCurrentLevelNumber(6)
+	l := lm.GetCurrentLevel()
+	tests := []struct {
+		objective     model.Objective
+		pushes, moves int
+	}{
+		{model.ObjectivePushes, 7, 31},
+		{model.ObjectiveMoves, 9, 29},
+		{model.ObjectivePushesMoves, 7, 31},
+	}
+	for _, test := range tests {
+		for _, workers := range []int{1, 4} {
+			r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Objective: test.objective, Workers: workers})
+			assert.NoError(t, err, "%v", test.objective)
+			assert.Equal(t, test.pushes, r.Pushes, "%v", test.objective)
+			assert.Equal(t, test.moves, len(r.Moves), "%v", test.objective)
+			b := model.NewBoard(l.MapData, l.Width, l.Height)
+			assert.Equal(t, r.Pushes, play(b, r.Moves), "%v", test.objective)
+			assert.True(t, b.IsComplete(), "%v", test.objective)
+		}
+	}
+
+	// level 1: as many pushes, fewer moves
+	lm.SetCurrentLevelNumber(1)
+	l = lm.GetCurrentLevel()
+	r, err := Solve(context.Background(), model.NewBoard(l.MapData, l.Width, l.Height), Options{Objective: model.ObjectivePushesMoves})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, r.Pushes)
+	assert.Equal(t, 10, len(r.Moves))
+
+	// pushes come first on 32-bit platforms too
+	pm := &level{objective: model.ObjectivePushesMoves}
+	assert.Less(t, pm.cost(1, 1<<20), pm.cost(2, 0))
+	assert.Less(t, pm.cost(2, 0), pm.cost(2, 1))
+}
@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"sort"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+)
+
+// occupancy - Returns which cells hold a box
+func (l *level) occupancy(boxes []int) []bool {
+	occ := make([]bool, len(l.walls))
+	for _, box := range boxes {
+		occ[box] = true
+	}
+	return occ
+}
+
+// reach - Walks from the player through the cells holding neither wall nor box, returns the distance of every cell and the normalised player position (the top-left most reachable cell)
+func (l *level) reach(occ []bool, player int) ([]int, int) {
+	dist := make([]int, len(l.walls))
+	for i := range dist {
+		dist[i] = unreachable
+	}
+	dist[player] = 0
+	region := player
+	queue := []int{player}
+	for head := 0; head < len(queue); head++ {
+		i := queue[head]
+		if i < region {
+			region = i
+		}
+		for _, dir := range dirs {
+			n := l.step(i, dir)
+			if l.isFloor(n) && !occ[n] && dist[n] == unreachable {
+				dist[n] = dist[i] + 1
+				queue = append(queue, n)
+			}
+		}
+	}
+	return dist, region
+}
+
+// path - Returns the shortest walk from one cell to another avoiding boxes (nil if there is none)
+func (l *level) path(occ []bool, from, to int) []direction.Direction {
+	if from == to {
+		return []direction.Direction{}
+	}
+	prev := make([]int, len(l.walls))
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[from] = from
+	queue := []int{from}
+	for head := 0; head < len(queue) && prev[to] == -1; head++ {
+		i := queue[head]
+		for _, dir := range dirs {
+			n := l.step(i, dir)
+			if l.isFloor(n) && !occ[n] && prev[n] == -1 {
+				prev[n] = i
+				queue = append(queue, n)
+			}
+		}
+	}
+	if prev[to] == -1 {
+		return nil
+	}
+	walk := []direction.Direction{}
+	for i := to; i != from; i = prev[i] {
+		walk = append(walk, l.direction(prev[i], i))
+	}
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk
+}
+
+// direction - Returns the direction leading from a cell to its neighbour
+func (l *level) direction(from, to int) direction.Direction {
+	switch to - from {
+	case -l.width:
+		return direction.U
+	case l.width:
+		return direction.D
+	case -1:
+		return direction.L
+	}
+	return direction.R
+}
+
+// solved - Returns true if every box stands on a goal
+func (l *level) solved(boxes []int) bool {
+	for _, box := range boxes {
+		if !l.isGoal[box] {
+			return false
+		}
+	}
+	return true
+}
+
+// movedBox - Returns a copy of the sorted boxes with one box moved
+func movedBox(boxes []int, from, to int) []int {
+	moved := make([]int, len(boxes))
+	copy(moved, boxes)
+	for i, box := range moved {
+		if box == from {
+			moved[i] = to
+			break
+		}
+	}
+	sort.Ints(moved)
+	return moved
+}
+
+// stateKey - Identifies a state by its boxes and normalised player position
+func stateKey(boxes []int, region int) string {
+	buf := make([]byte, 0, 2*len(boxes)+2)
+	for _, box := range boxes {
+		buf = append(buf, byte(box>>8), byte(box))
+	}
+	buf = append(buf, byte(region>>8), byte(region))
+	return string(buf)
+}
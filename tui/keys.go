@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// escapeKeys - The escape sequences of the keys with no character of their own (normal and application cursor modes)
+var escapeKeys = map[string]string{
+	"\x1b[A": "Up", "\x1b[B": "Down", "\x1b[C": "Right", "\x1b[D": "Left",
+	"\x1bOA": "Up", "\x1bOB": "Down", "\x1bOC": "Right", "\x1bOD": "Left",
+	"\x1b[H": "Home", "\x1b[F": "End", "\x1b[2~": "Insert", "\x1b[3~": "Delete",
+	"\x1b[5~": "PageUp", "\x1b[6~": "PageDown",
+}
+
+// ParseKeys - Returns the names of the keys typed in raw terminal input, the ones keymaps use (see pixelgl.Button.String): "Up", "Space", "Enter", "A", "7"...
+// Ctrl+C comes back as "Ctrl+C", a lone escape as "Escape", unknown escape sequences are dropped
+func ParseKeys(input []byte) []string {
+	keys := []string{}
+	for len(input) > 0 {
+		if input[0] == 0x1b {
+			if len(input) == 1 || (input[1] != '[' && input[1] != 'O') {
+				keys = append(keys, "Escape")
+				input = input[1:]
+				continue
+			}
+			// a sequence ends on its first letter or tilde past the introducer
+			end := 2
+			for end < len(input) && !isFinal(input[end]) {
+				end++
+			}
+			if end == len(input) {
+				return keys
+			}
+			if key, ok := escapeKeys[string(input[:end+1])]; ok {
+				keys = append(keys, key)
+			}
+			input = input[end+1:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(input)
+		input = input[size:]
+		switch {
+		case r == 0x03:
+			keys = append(keys, "Ctrl+C")
+		case r == '\r' || r == '\n':
+			keys = append(keys, "Enter")
+		case r == ' ':
+			keys = append(keys, "Space")
+		case r == '\t':
+			keys = append(keys, "Tab")
+		case r == 0x7f || r == 0x08:
+			keys = append(keys, "Backspace")
+		case r > ' ' && r != utf8.RuneError:
+			keys = append(keys, strings.ToUpper(string(r)))
+		}
+	}
+	return keys
+}
+
+func isFinal(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || c == '~'
+}
@@ -0,0 +1,16 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []string{"Up", "Down", "Right", "Left"}, ParseKeys([]byte("\x1b[A\x1b[B\x1bOC\x1b[D")))
+	assert.Equal(t, []string{"Z", "Z", "Space", "Enter", "7", "Backspace", "Tab"}, ParseKeys([]byte("zZ \r7\x7f\t")))
+	assert.Equal(t, []string{"Escape"}, ParseKeys([]byte("\x1b")))
+	assert.Equal(t, []string{"Ctrl+C"}, ParseKeys([]byte{0x03}))
+	assert.Equal(t, []string{"PageUp", "A"}, ParseKeys([]byte("\x1b[5~\x1b[1;5Pa")))
+	assert.Equal(t, []string{"B"}, ParseKeys([]byte("b\x1b[1")))
+}
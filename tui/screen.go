@@ -0,0 +1,235 @@
+// Package tui draws the game in a terminal with ANSI colours and decodes its keystrokes, the frontend of cmd/sokoban-tui
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheInvader360/sokoban-go/bindings"
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// ANSI attributes of the board cells, a cell is two characters wide to look about square
+const (
+	reset     = "\x1b[0m"
+	grey      = "\x1b[90m"
+	red       = "\x1b[31m"
+	green     = "\x1b[32m"
+	yellow    = "\x1b[33m"
+	magenta   = "\x1b[35m"
+	cyan      = "\x1b[1;36m"
+	bold      = "\x1b[1m"
+	freeBg    = "\x1b[44m" // free space of the player (hints on)
+	pathBg    = "\x1b[43m" // best path to the next push
+	deadBg    = "\x1b[41m" // free space a box shall never be pushed to
+	boardLeft = 2          // columns left of the board
+	boardTop  = 3          // rows above the board (search status)
+)
+
+// Screen - Draws a model as text frames, the controls listed are the keymap's
+type Screen struct {
+	m      *model.Model
+	keymap *bindings.Keymap
+}
+
+// NewScreen - Creates a screen
+func NewScreen(m *model.Model, keymap *bindings.Keymap) *Screen {
+	return &Screen{m: m, keymap: keymap}
+}
+
+// Frame - Returns the whole screen, lines ended by "\r\n" (raw terminals do not return the carriage themselves)
+func (s *Screen) Frame(showFreeSpace bool) string {
+	lines := s.status()
+	if s.m.Notice != "" {
+		lines = append(lines, s.m.Notice)
+	}
+	for len(lines) < boardTop {
+		lines = append(lines, "")
+	}
+	board := []string{}
+	panel := []string{}
+	switch s.m.State {
+	case model.StatePlaying:
+		board = s.board(showFreeSpace)
+		panel = append(s.scores(), s.controls("Controls", controller.Actions()[:controller.SaveSolution])...)
+	case model.StateLevelComplete:
+		board = s.board(showFreeSpace)
+		panel = s.scores()
+		if s.m.TickAccumulator < 10 {
+			panel = append(panel, bold+"LEVEL COMPLETE"+reset)
+		} else {
+			panel = append(panel, "")
+		}
+		panel = append(panel, s.controls("Controls", []controller.Action{controller.Confirm, controller.SaveSolution})...)
+	case model.StateGameComplete:
+		panel = []string{bold + "GAME COMPLETE!" + reset}
+		if s.m.TickAccumulator < 10 {
+			panel = append(panel, "CONGRATULATIONS!")
+		}
+		panel = append(panel, s.controls("Controls", []controller.Action{controller.Confirm})...)
+	}
+
+	width := 0
+	if len(board) > 0 {
+		width = 2 * s.m.Board.Width
+	}
+	for i := 0; i < len(board) || i < len(panel); i++ {
+		line := strings.Repeat(" ", boardLeft)
+		if i < len(board) {
+			line += board[i]
+		} else {
+			line += strings.Repeat(" ", width)
+		}
+		if i < len(panel) {
+			line += "   " + panel[i]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+// status - Returns the hint search status lines (progress while it runs), as the pixel view prints them in its top left corner
+func (s *Screen) status() []string {
+	p := message.NewPrinter(language.English)
+	lines := []string{}
+	switch {
+	case s.m.State == model.StateGameComplete:
+		return lines
+	case s.m.Search.Running:
+		lines = append(lines, p.Sprintf("Searching... bound %d", s.m.Search.Bound))
+	case s.m.Search.Expired:
+		lines = append(lines, "No hint available")
+	default:
+		lines = append(lines, p.Sprintf("Solve Duration : %02d ns", s.m.SolveDuration))
+	}
+	if t := s.m.Search.Table; t.Hits+t.Misses > 0 {
+		lines = append(lines, p.Sprintf("Boards : %02d  Hits : %d%%  %d MB", s.m.Search.Explored, 100*t.Hits/(t.Hits+t.Misses), t.Bytes>>20))
+	} else {
+		lines = append(lines, p.Sprintf("Boards : %02d", s.m.Search.Explored))
+	}
+	return lines
+}
+
+// scores - Returns the level, objective, moves and hints lines of the side panel
+func (s *Screen) scores() []string {
+	fewest := "~Moves"
+	if s.m.Hints == model.HintBackendSolver {
+		fewest = s.m.Objective.String()
+	}
+	return []string{
+		fmt.Sprintf("Level %02d of %02d", s.m.LM.GetCurrentLevelNumber(), s.m.LM.GetFinalLevelNumber()),
+		fmt.Sprintf("Fewest %8s", fewest),
+		fmt.Sprintf("Moves %02d/%02d/%02d", s.m.Moves, s.m.BestMoves, s.m.Moves+s.m.Board.GetBestPosition().BestLength),
+		fmt.Sprintf("Hints %9s", s.m.Hints),
+		"",
+	}
+}
+
+// controls - Returns the given actions with their first key, the quit keys last
+func (s *Screen) controls(title string, actions []controller.Action) []string {
+	lines := []string{"---" + title + "---"}
+	for _, action := range actions {
+		keys := s.keymap.Keys[action]
+		if len(keys) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%-7s %v", keys[0]+":", action))
+	}
+	return append(lines, fmt.Sprintf("%-7s %s", "Escape:", "Quit"))
+}
+
+// board - Returns the board rows, with the free space, best path and box moves when showFreeSpace is set (see view.View.drawBoard)
+func (s *Screen) board(showFreeSpace bool) []string {
+	b := s.m.Board
+	rows := make([]string, b.Height)
+	for y := 0; y < b.Height; y++ {
+		var row strings.Builder
+		for x := 0; x < b.Width; x++ {
+			row.WriteString(s.cell(x, y, showFreeSpace))
+		}
+		rows[y] = row.String()
+	}
+	return rows
+}
+
+// cell - Returns the two characters of board cell x,y
+func (s *Screen) cell(x, y int, showFreeSpace bool) string {
+	b := s.m.Board
+	cell := b.Get(x, y)
+	if cell.TypeOf == model.CellTypeWall {
+		return grey + "██" + reset
+	}
+
+	bg := ""
+	if showFreeSpace && cell.IsFree && !cell.HasBox {
+		switch {
+		case cell.IsPath:
+			bg = pathBg
+		case b.IsDeadCell(x, y) && cell.TypeOf != model.CellTypeGoal:
+			bg = deadBg
+		default:
+			bg = freeBg
+		}
+	}
+
+	switch {
+	case b.Player.X == x && b.Player.Y == y:
+		if cell.TypeOf == model.CellTypeGoal {
+			return bg + cyan + "@" + magenta + ")" + reset
+		}
+		return bg + cyan + "@ " + reset
+	case cell.HasBox:
+		box := &b.Boxes[cell.Box]
+		colour := yellow
+		if cell.TypeOf == model.CellTypeGoal {
+			colour = green
+		}
+		if showFreeSpace && box.IsDead && cell.TypeOf != model.CellTypeGoal {
+			return red + "><" + reset
+		}
+		if showFreeSpace {
+			return s.boxArrows(box, x, y, colour)
+		}
+		return colour + "[]" + reset
+	case cell.TypeOf == model.CellTypeGoal:
+		return bg + magenta + "()" + reset
+	case bg != "":
+		return bg + "  " + reset
+	}
+	return "  "
+}
+
+// boxArrows - Returns a box showing where it can go: the vertical moves on the left, the horizontal ones on the right.
+// An arrow is bold green for the best move, green if the move may lead to a solution, red if it shall not be played (see model.Box.ShallNotMove)
+func (s *Screen) boxArrows(box *model.Box, x, y int, colour string) string {
+	best := s.m.Board.GetBestPosition()
+	axis := func(first, second direction.Direction, both, one, other, none string) string {
+		glyph := none
+		switch {
+		case box.CanMove[first] && box.CanMove[second]:
+			glyph = both
+		case box.CanMove[first]:
+			glyph = one
+		case box.CanMove[second]:
+			glyph = other
+		default:
+			return colour + glyph + reset
+		}
+		attr := red
+		for _, dir := range []direction.Direction{first, second} {
+			if !box.CanMove[dir] || box.ShallNotMove[dir] {
+				continue
+			}
+			if best.BestX == x && best.BestY == y && best.BestDir == dir {
+				return bold + green + glyph + reset
+			}
+			attr = green
+		}
+		return attr + glyph + reset
+	}
+	return axis(direction.U, direction.D, "↕", "↑", "↓", "[") + axis(direction.L, direction.R, "↔", "←", "→", "]")
+}
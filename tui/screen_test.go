@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/bindings"
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var ansi = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plain - Returns the lines of a frame without their colours
+func plain(frame string) []string {
+	return strings.Split(strings.TrimSuffix(ansi.ReplaceAllString(frame, ""), "\r\n"), "\r\n")
+}
+
+func TestFrame(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false), Boards: model.NewTable(0)}
+	c := controller.NewController(&m)
+	c.StartNewGame()
+	c.WaitForHints()
+	s := NewScreen(&m, bindings.Default())
+
+	lines := plain(s.Frame(false))
+	assert.Equal(t, "Boards : 28  Hits : 54%  0 MB", lines[1])
+	assert.Equal(t, "      ██()██         Fewest   ~Moves", lines[4])
+	assert.Equal(t, "  ██████[]  []()██   Hints     Board", lines[6])
+	assert.Equal(t, "  ██()  []@ ██████   ", lines[7])
+	assert.Equal(t, "        ██()██       Up:     MoveUp", lines[9])
+	assert.Equal(t, "                     Escape: Quit", lines[len(lines)-1])
+
+	// the hints: free space, best path and box moves (the best one in bold)
+	frame := s.Frame(true)
+	lines = plain(frame)
+	assert.Equal(t, "  ██████[]  [→()██   Hints     Board", lines[6])
+	assert.Equal(t, "  ██()  [←@ ██████   ", lines[7])
+	assert.Contains(t, frame, freeBg+cyan+"@ ")
+	assert.Contains(t, frame, bold+green+"↓")
+	assert.Contains(t, frame, reset+green+"←")
+	assert.NotContains(t, frame, red)
+	c.HandleInput(controller.MoveDown)
+	c.WaitForHints()
+	frame = s.Frame(true)
+	assert.Equal(t, "  ██()  [←  ██████   ", plain(frame)[7])
+	assert.Contains(t, frame, pathBg+"  ")
+	assert.Contains(t, frame, bold+green+"←")
+	c.HandleInput(controller.Undo)
+	c.WaitForHints()
+
+	// the solver objective and remapped keys
+	c.HandleInput(controller.ToggleHintBackend)
+	c.WaitForHints()
+	keymap := bindings.Default()
+	keymap.Keys[controller.MoveUp] = []string{"W", "Up"}
+	keymap.Keys[controller.LoadSolution] = nil
+	lines = plain(NewScreen(&m, keymap).Frame(false))
+	assert.Equal(t, "      ██()██         Fewest   Pushes", lines[4])
+	assert.Equal(t, "        ██()██       W:      MoveUp", lines[9])
+	assert.NotContains(t, strings.Join(lines, "\n"), "LoadSolution")
+
+	// level and game complete
+	m.State = model.StateLevelComplete
+	m.TickAccumulator = 0
+	lines = plain(s.Frame(false))
+	assert.Equal(t, "  ████████[]██       LEVEL COMPLETE", lines[8])
+	assert.Equal(t, "        ██████       Space:  Confirm", lines[10])
+	m.State = model.StateGameComplete
+	lines = plain(s.Frame(false))
+	assert.Equal(t, []string{"", "", "", "     GAME COMPLETE!", "     CONGRATULATIONS!", "     ---Controls---", "     Space:  Confirm", "     Escape: Quit"}, lines)
+}
@@ -0,0 +1,68 @@
+package view
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+	"golang.org/x/image/colornames"
+)
+
+// BoardImage - Draws the board (without hints) on black in an image of its own size, a board cell is CellSize*scale pixels wide
+func BoardImage(b *model.Board, sheet image.Image, scale int) *image.RGBA {
+	r := newImageRenderer(b.Width*CellSize, b.Height*CellSize, scale, sheet)
+	v := View{m: &model.Model{Board: b}, r: r}
+	r.Clear(colornames.Black)
+	v.drawBoardAt(false, boardLayout{tile: CellSize, visible: image.Rect(0, 0, b.Width, b.Height)})
+	return r.Image
+}
+
+// SolutionGIF - Replays the moves on the level into an animation: the start, then a frame per move shown for delay (100ths of a second), the last one held a second longer.
+// It stops with a model.IllegalMoveError at the first move breaking the rules (see model.Verify)
+func SolutionGIF(l model.Level, moves []direction.Direction, sheet image.Image, scale, delay int) (*gif.GIF, error) {
+	b := model.NewBoard(l.MapData, l.Width, l.Height)
+	pal := sheetPalette(sheet)
+	anim := &gif.GIF{}
+	addFrame := func() {
+		frame := BoardImage(b, sheet, scale)
+		paletted := image.NewPaletted(frame.Bounds(), pal)
+		draw.Draw(paletted, paletted.Rect, frame, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	addFrame()
+	for i, dir := range moves {
+		if _, err := b.PlayMove(dir); err != nil {
+			return nil, &model.IllegalMoveError{Index: i, Dir: dir, Reason: err}
+		}
+		addFrame()
+	}
+	anim.Delay[len(anim.Delay)-1] += 100
+	return anim, nil
+}
+
+// sheetPalette - Returns the colours of the spritesheet (and black) if they fit a GIF palette, a general purpose palette if not
+func sheetPalette(sheet image.Image) color.Palette {
+	pal := color.Palette{color.RGBA{0, 0, 0, 0xff}}
+	seen := map[color.RGBA]bool{pal[0].(color.RGBA): true}
+	bounds := sheet.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.RGBAModel.Convert(sheet.At(x, y)).(color.RGBA)
+			if c.A != 0xff || seen[c] {
+				continue
+			}
+			if len(pal) == 256 {
+				return palette.Plan9
+			}
+			seen[c] = true
+			pal = append(pal, c)
+		}
+	}
+	return pal
+}
@@ -0,0 +1,65 @@
+package view
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/direction"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// corridor - A level solved by pushing its box right twice (rRR)
+var corridor = model.Level{MapData: "########@ $ .########", Width: 7, Height: 3}
+
+// cellIs - Returns true if board cell x,y of the image holds the sprite, as drawn on black
+func cellIs(img image.Image, sheet image.Image, scale int, s Sprite, x, y int) bool {
+	frame := SpriteFrames[s]
+	for py := 0; py < CellSize*scale; py++ {
+		for px := 0; px < CellSize*scale; px++ {
+			want := color.RGBAModel.Convert(sheet.At(frame.Min.X+px/scale, frame.Min.Y+py/scale)).(color.RGBA)
+			got := color.RGBAModel.Convert(img.At(x*CellSize*scale+px, y*CellSize*scale+py)).(color.RGBA)
+			if want.A == 0xff && want != got {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestBoardImage(t *testing.T) {
+	sheet, err := LoadSpritesheet("../assets/spritesheet.png")
+	assert.NoError(t, err)
+	b := model.NewBoard(corridor.MapData, corridor.Width, corridor.Height)
+	img := BoardImage(b, sheet, 2)
+	assert.Equal(t, image.Rect(0, 0, 7*CellSize*2, 3*CellSize*2), img.Bounds())
+	assert.True(t, cellIs(img, sheet, 2, SpriteWall, 0, 0))
+	assert.True(t, cellIs(img, sheet, 2, SpritePlayer, 1, 1))
+	assert.True(t, cellIs(img, sheet, 2, SpriteBox, 3, 1))
+	assert.True(t, cellIs(img, sheet, 2, SpriteGoal, 5, 1))
+	assert.Equal(t, color.RGBA{0, 0, 0, 0xff}, img.RGBAAt(2*CellSize*2, CellSize*2)) // free cell
+}
+
+func TestSolutionGIF(t *testing.T) {
+	sheet, err := LoadSpritesheet("../assets/spritesheet.png")
+	assert.NoError(t, err)
+	anim, err := SolutionGIF(corridor, []direction.Direction{direction.R, direction.R, direction.R}, sheet, 1, 15)
+	assert.NoError(t, err)
+	assert.Len(t, anim.Image, 4)
+	assert.Equal(t, []int{15, 15, 15, 115}, anim.Delay)
+	assert.Equal(t, image.Rect(0, 0, 7*CellSize, 3*CellSize), anim.Image[0].Bounds())
+	assert.True(t, cellIs(anim.Image[0], sheet, 1, SpriteBox, 3, 1))
+	assert.True(t, cellIs(anim.Image[3], sheet, 1, SpritePlayer, 4, 1))
+	assert.True(t, cellIs(anim.Image[3], sheet, 1, SpriteGoalAndBox, 5, 1))
+
+	// into the wall, then past the end of the level
+	_, err = SolutionGIF(corridor, []direction.Direction{direction.L}, sheet, 1, 15)
+	var illegal *model.IllegalMoveError
+	assert.True(t, errors.As(err, &illegal))
+	assert.Equal(t, 0, illegal.Index)
+	assert.ErrorIs(t, err, model.ErrPlayerBlocked)
+	_, err = SolutionGIF(corridor, []direction.Direction{direction.R, direction.R, direction.R, direction.L}, sheet, 1, 15)
+	assert.ErrorIs(t, err, model.ErrLevelComplete)
+}
@@ -0,0 +1,71 @@
+// Package glrender draws the view in a pixel OpenGL window
+package glrender
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/TheInvader360/sokoban-go/view"
+	"github.com/golang/freetype/truetype"
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"golang.org/x/image/colornames"
+)
+
+// Renderer - A view.Renderer drawing in a window scaled up by scale
+type Renderer struct {
+	win     *opengl.Window
+	scale   float64
+	text    *text.Text
+	sprites []*pixel.Sprite
+}
+
+// New - Creates a renderer of the window, with the spritesheet and font of the game
+func New(win *opengl.Window, scale float64, sheet image.Image, ttf *truetype.Font) *Renderer {
+	atlas := text.NewAtlas(view.NewFace(ttf, scale), text.ASCII)
+	txt := text.New(pixel.V(0, 0), atlas)
+	txt.LineHeight = view.LineHeight * scale
+	txt.Color = colornames.White
+
+	// pixel pictures have their origin bottom left
+	pictureData := pixel.PictureDataFromImage(sheet)
+	h := pictureData.Bounds().H()
+	sprites := make([]*pixel.Sprite, len(view.SpriteFrames))
+	for i, f := range view.SpriteFrames {
+		f = f.Sub(sheet.Bounds().Min)
+		sprites[i] = pixel.NewSprite(pictureData, pixel.R(float64(f.Min.X), h-float64(f.Max.Y), float64(f.Max.X), h-float64(f.Min.Y)))
+	}
+
+	return &Renderer{win: win, scale: scale, text: txt, sprites: sprites}
+}
+
+// Clear - Fills the window with c
+func (r *Renderer) Clear(c color.Color) {
+	r.win.Clear(c)
+}
+
+// DrawSprite - Draws the sprite stretched over rect
+func (r *Renderer) DrawSprite(s view.Sprite, rect image.Rectangle, tint color.Color) {
+	h := r.win.Bounds().H()
+	dst := pixel.R(float64(rect.Min.X)*r.scale, h-float64(rect.Max.Y)*r.scale, float64(rect.Max.X)*r.scale, h-float64(rect.Min.Y)*r.scale)
+	sprite := r.sprites[s]
+	m := pixel.IM.ScaledXY(pixel.ZV, pixel.V(dst.W()/sprite.Frame().W(), dst.H()/sprite.Frame().H())).Moved(dst.Center())
+	if tint == nil {
+		sprite.Draw(r.win, m)
+	} else {
+		sprite.DrawColorMask(r.win, m, tint)
+	}
+}
+
+// DrawText - Prints the text, its first baseline at the bottom of text cell col,row
+func (r *Renderer) DrawText(s string, col, row int) {
+	r.text.Clear()
+	r.text.WriteString(s)
+	r.text.Draw(r.win, pixel.IM.Moved(pixel.V(float64(col*view.CharWidth)*r.scale, r.win.Bounds().H()-float64((row+1)*view.LineHeight)*r.scale)))
+}
+
+// Present - Swaps the window buffers
+func (r *Renderer) Present() {
+	r.win.Update()
+}
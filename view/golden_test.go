@@ -0,0 +1,129 @@
+package view
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images of testdata/ with what the view draws now")
+
+// goldenPack - A level with a box frozen in a corner, dead cells along the walls and live boxes, then a level solved by pushing right twice (rRR)
+const goldenPack = `#######
+#$    #
+#  $  #
+# @ ..#
+#   $.#
+#######
+Title: Dead box
+
+#######
+#@ $ .#
+#######
+Title: Corridor
+`
+
+// newGoldenGame - Returns a model and its controller on level n of the built-in levels, or of goldenPack if pack is set, its hints searched
+func newGoldenGame(t *testing.T, pack bool, n int) (*model.Model, *controller.Controller) {
+	m := model.Model{LM: model.NewLevelManager(false), Boards: model.NewTable(0)}
+	if pack {
+		levels, err := model.ParseLevels(strings.NewReader(goldenPack))
+		assert.NoError(t, err)
+		m.LM = model.NewLevelManagerFromLevels(levels)
+	}
+	assert.True(t, m.LM.SetCurrentLevelNumber(n))
+	c := controller.NewController(&m)
+	c.HandleInput(controller.Restart)
+	c.WaitForHints()
+	return &m, c
+}
+
+// assertGolden - Draws the model and compares the screen with testdata/name.png, pixel for pixel (rewrites it with -update).
+// The search figures are pinned first, the images would change with the search speed and internals otherwise
+func assertGolden(t *testing.T, name string, m *model.Model, showFreeSpace bool) {
+	m.SolveDuration = 123456
+	m.Search.Explored = 42
+	m.Search.Table = model.TableStats{}
+	r := newTestRenderer(t, 1)
+	NewView(m, r).Draw(showFreeSpace)
+
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		f, err := os.Create(path)
+		assert.NoError(t, err)
+		assert.NoError(t, png.Encode(f, r.Image))
+		assert.NoError(t, f.Close())
+		return
+	}
+	f, err := os.Open(path)
+	if !assert.NoError(t, err, "run go test ./view -update to create it") {
+		return
+	}
+	defer f.Close()
+	golden, err := png.Decode(f)
+	assert.NoError(t, err)
+	assert.Equal(t, golden.Bounds(), r.Image.Bounds())
+	if diff := imageDiff(golden, r.Image); diff != "" {
+		t.Errorf("%s: %s (run go test ./view -update if the change is intended)", path, diff)
+	}
+}
+
+// imageDiff - Describes where two images differ, "" if they do not
+func imageDiff(want image.Image, got *image.RGBA) string {
+	count, bounds := 0, image.Rectangle{}
+	for y := got.Rect.Min.Y; y < got.Rect.Max.Y; y++ {
+		for x := got.Rect.Min.X; x < got.Rect.Max.X; x++ {
+			r1, g1, b1, a1 := want.At(x, y).RGBA()
+			r2, g2, b2, a2 := got.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				count++
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d pixels differ within %v", count, bounds)
+}
+
+func TestGoldenPlaying(t *testing.T) {
+	m, _ := newGoldenGame(t, false, 1)
+	assertGolden(t, "playing", m, false)
+	assertGolden(t, "hints", m, true)
+}
+
+func TestGoldenDeadBox(t *testing.T) {
+	m, _ := newGoldenGame(t, true, 1)
+	assert.True(t, m.Board.Boxes[m.Board.Get(1, 1).Box].IsDead)
+	assertGolden(t, "dead_box", m, true)
+}
+
+func TestGoldenLevelComplete(t *testing.T) {
+	m, c := newGoldenGame(t, true, 2)
+	assert.NoError(t, c.ReplaySolution("rRR"))
+	c.WaitForHints()
+	assert.Equal(t, model.StateLevelComplete, m.State)
+	m.TickAccumulator = 0
+	assertGolden(t, "level_complete", m, false)
+}
+
+func TestGoldenGameComplete(t *testing.T) {
+	m, c := newGoldenGame(t, true, 2)
+	assert.NoError(t, c.ReplaySolution("rRR"))
+	c.HandleInput(controller.Confirm)
+	assert.Equal(t, model.StateGameComplete, m.State)
+	m.TickAccumulator = 0
+	assertGolden(t, "game_complete", m, false)
+	m.TickAccumulator = 10
+	assertGolden(t, "game_complete_border", m, false)
+}
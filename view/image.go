@@ -0,0 +1,92 @@
+package view
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ImageRenderer - A Renderer drawing in memory, to snapshot or export the screen without a window
+type ImageRenderer struct {
+	Image  *image.RGBA // what was drawn since Clear
+	scale  int
+	sheet  image.Image
+	face   font.Face
+	Frames int // presented so far
+}
+
+// NewImageRenderer - Creates a renderer of a ScreenWidth x ScreenHeight screen scaled up by scale, with the spritesheet and font of the game
+func NewImageRenderer(scale int, sheet image.Image, ttf *truetype.Font) *ImageRenderer {
+	r := newImageRenderer(ScreenWidth, ScreenHeight, scale, sheet)
+	r.face = NewFace(ttf, float64(scale))
+	return r
+}
+
+// newImageRenderer - Creates a renderer of a width x height screen (in screen units) that does not print text
+func newImageRenderer(width, height, scale int, sheet image.Image) *ImageRenderer {
+	return &ImageRenderer{
+		Image: image.NewRGBA(image.Rect(0, 0, width*scale, height*scale)),
+		scale: scale,
+		sheet: sheet,
+	}
+}
+
+// Clear - Fills the image with c
+func (r *ImageRenderer) Clear(c color.Color) {
+	draw.Draw(r.Image, r.Image.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// DrawSprite - Draws the sprite over the image (nearest neighbour scaling, as pixel draws its sprites)
+func (r *ImageRenderer) DrawSprite(s Sprite, rect image.Rectangle, tint color.Color) {
+	frame := SpriteFrames[s].Add(r.sheet.Bounds().Min)
+	dst := image.Rect(rect.Min.X*r.scale, rect.Min.Y*r.scale, rect.Max.X*r.scale, rect.Max.Y*r.scale)
+	tr, tg, tb, ta := uint32(0xffff), uint32(0xffff), uint32(0xffff), uint32(0xffff)
+	if tint != nil {
+		tr, tg, tb, ta = tint.RGBA()
+	}
+	for y := dst.Min.Y; y < dst.Max.Y; y++ {
+		sy := frame.Min.Y + (y-dst.Min.Y)*frame.Dy()/dst.Dy()
+		for x := dst.Min.X; x < dst.Max.X; x++ {
+			if !(image.Point{x, y}.In(r.Image.Rect)) {
+				continue
+			}
+			sx := frame.Min.X + (x-dst.Min.X)*frame.Dx()/dst.Dx()
+			sr, sg, sb, sa := r.sheet.At(sx, sy).RGBA()
+			sr, sg, sb, sa = sr*tr/0xffff, sg*tg/0xffff, sb*tb/0xffff, sa*ta/0xffff
+			if sa == 0 {
+				continue
+			}
+			// source over destination, both premultiplied
+			d := r.Image.RGBAAt(x, y)
+			keep := 0xffff - sa
+			r.Image.SetRGBA(x, y, color.RGBA{
+				R: uint8((sr + uint32(d.R)*0x101*keep/0xffff) >> 8),
+				G: uint8((sg + uint32(d.G)*0x101*keep/0xffff) >> 8),
+				B: uint8((sb + uint32(d.B)*0x101*keep/0xffff) >> 8),
+				A: uint8((sa + uint32(d.A)*0x101*keep/0xffff) >> 8),
+			})
+		}
+	}
+}
+
+// DrawText - Prints the text in white (nothing without a font)
+func (r *ImageRenderer) DrawText(s string, col, row int) {
+	if r.face == nil {
+		return
+	}
+	d := font.Drawer{Dst: r.Image, Src: image.White, Face: r.face}
+	for i, line := range strings.Split(s, "\n") {
+		d.Dot = fixed.P(col*CharWidth*r.scale, (row+i+1)*LineHeight*r.scale)
+		d.DrawString(line)
+	}
+}
+
+// Present - Counts the frame, the image holds it until the next Clear
+func (r *ImageRenderer) Present() {
+	r.Frames++
+}
@@ -0,0 +1,105 @@
+package view
+
+import (
+	"image"
+
+	"github.com/TheInvader360/sokoban-go/model"
+)
+
+// playArea - The screen region the board is drawn in, left of the side panel (21x14 board cells at full size)
+var playArea = image.Rect(CellSize, CellSize, 22*CellSize, 15*CellSize)
+
+const (
+	minTile  = 4            // the smallest tile a board is fitted in, larger boards scroll
+	maxTile  = 2 * CellSize // the largest tile a board is zoomed in to
+	zoomStep = 4            // the tile growth of a model.Model Zoom step
+)
+
+// boardLayout - Where the board is drawn: board cell x,y over the tile x tile square at origin+(x,y)*tile, for the cells of visible only
+type boardLayout struct {
+	origin  image.Point
+	tile    int
+	visible image.Rectangle // board cells
+}
+
+// cell - Returns the screen rectangle of board cell x,y
+func (l boardLayout) cell(x, y int) image.Rectangle {
+	min := l.origin.Add(image.Pt(x, y).Mul(l.tile))
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(l.tile, l.tile))}
+}
+
+// shows - Returns true if board cell x,y is drawn
+func (l boardLayout) shows(x, y int) bool {
+	return image.Pt(x, y).In(l.visible)
+}
+
+// fitTile - Returns the largest tile (CellSize at most) a width x height board fits the play area in, minTile if none does
+func fitTile(width, height int) int {
+	tile := CellSize
+	if width > 0 && playArea.Dx()/width < tile {
+		tile = playArea.Dx() / width
+	}
+	if height > 0 && playArea.Dy()/height < tile {
+		tile = playArea.Dy() / height
+	}
+	if tile < minTile {
+		tile = minTile
+	}
+	return tile
+}
+
+// zoomTile - Returns the tile of a width x height board zoomed in zoom steps (out if negative) from the size that fits it
+func zoomTile(width, height, zoom int) int {
+	tile := fitTile(width, height) + zoom*zoomStep
+	if tile < minTile {
+		return minTile
+	}
+	if tile > maxTile {
+		return maxTile
+	}
+	return tile
+}
+
+// layoutBoard - Returns the layout of the board in the play area at the model's zoom: centred along the sides it fits,
+// scrolled along the others, the camera following the player so that it stays a quarter of the play area away from the edges
+func (v *View) layoutBoard(b *model.Board) boardLayout {
+	l := boardLayout{tile: zoomTile(b.Width, b.Height, v.m.Zoom), origin: playArea.Min, visible: image.Rect(0, 0, b.Width, b.Height)}
+	cols, rows := playArea.Dx()/l.tile, playArea.Dy()/l.tile
+	v.camera.X = follow(v.camera.X, b.Player.X, b.Width, cols)
+	v.camera.Y = follow(v.camera.Y, b.Player.Y, b.Height, rows)
+
+	if b.Width <= cols {
+		l.origin.X += (cols - b.Width + 1) / 2 * l.tile // rounded right, as boards were centred over 22 columns
+	} else {
+		l.origin.X -= v.camera.X * l.tile
+		l.visible.Min.X, l.visible.Max.X = v.camera.X, v.camera.X+cols
+	}
+	if b.Height <= rows {
+		l.origin.Y += (rows - b.Height) / 2 * l.tile
+	} else {
+		l.origin.Y -= v.camera.Y * l.tile
+		l.visible.Min.Y, l.visible.Max.Y = v.camera.Y, v.camera.Y+rows
+	}
+	return l
+}
+
+// follow - Returns the first of the size cells shown out of length, moved from first as little as needed to keep the player a quarter of size away from the edges (0 if all fit)
+func follow(first, player, length, size int) int {
+	if length <= size {
+		return 0
+	}
+	margin := size / 4
+	if player-margin < first {
+		first = player - margin
+	}
+	if player+margin >= first+size {
+		first = player + margin - size + 1
+	}
+	if first > length-size {
+		first = length - size
+	}
+	if first < 0 {
+		first = 0
+	}
+	return first
+}
@@ -0,0 +1,94 @@
+package view
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitTile(t *testing.T) {
+	assert.Equal(t, CellSize, fitTile(8, 8))
+	assert.Equal(t, CellSize, fitTile(21, 14))
+	assert.Equal(t, 15, fitTile(22, 14))
+	assert.Equal(t, 11, fitTile(30, 14))
+	assert.Equal(t, 8, fitTile(30, 28))
+	assert.Equal(t, minTile, fitTile(200, 100))
+
+	assert.Equal(t, CellSize+zoomStep, zoomTile(8, 8, 1))
+	assert.Equal(t, maxTile, zoomTile(8, 8, model.MaxZoom))
+	assert.Equal(t, minTile, zoomTile(8, 8, -model.MaxZoom))
+}
+
+func TestFollow(t *testing.T) {
+	// the whole board fits
+	assert.Equal(t, 0, follow(5, 3, 10, 10))
+	// the player stays a quarter of the shown cells (2 of 8) away from the edges
+	assert.Equal(t, 0, follow(0, 5, 40, 8))
+	assert.Equal(t, 1, follow(0, 6, 40, 8))
+	assert.Equal(t, 10, follow(12, 12, 40, 8))
+	// but the camera stops at the board edges
+	assert.Equal(t, 0, follow(3, 1, 40, 8))
+	assert.Equal(t, 32, follow(20, 39, 40, 8))
+}
+
+// wideLevel - A 100x5 level, too wide for the play area even at the smallest tile
+func wideLevel() *model.Board {
+	rows := []string{
+		strings.Repeat("#", 100),
+		"#@" + strings.Repeat(" ", 97) + "#",
+		"# $" + strings.Repeat(" ", 95) + ".#",
+		"#" + strings.Repeat(" ", 98) + "#",
+		strings.Repeat("#", 100),
+	}
+	return model.NewBoard(strings.Join(rows, ""), 100, 5)
+}
+
+func TestLayoutBoard(t *testing.T) {
+	m := &model.Model{}
+	v := NewView(m, nil)
+
+	// a board that fits is centred at full size, as ever
+	m.Board = model.NewBoard("#####@$.#####", 13, 1)
+	l := v.layoutBoard(m.Board)
+	assert.Equal(t, CellSize, l.tile)
+	assert.Equal(t, image.Pt(5*CellSize, 7*CellSize), l.origin)
+	assert.Equal(t, image.Rect(0, 0, 13, 1), l.visible)
+
+	// a larger one shrinks to fit, it scrolls past minTile
+	m.Board = wideLevel()
+	l = v.layoutBoard(m.Board)
+	assert.Equal(t, minTile, l.tile)
+	assert.Equal(t, image.Rect(0, 0, 84, 5), l.visible)
+
+	// zoomed in, the camera follows the player along the side that does not fit and stays within the play area
+	m.Zoom = 3
+	l = v.layoutBoard(m.Board)
+	assert.Equal(t, minTile+3*zoomStep, l.tile)
+	assert.Equal(t, image.Rect(0, 0, 21, 5), l.visible)
+	m.Board.Player.X = 40
+	l = v.layoutBoard(m.Board)
+	assert.Equal(t, image.Rect(25, 0, 46, 5), l.visible)
+	assert.True(t, l.shows(40, 1))
+	assert.False(t, l.shows(24, 1))
+	for _, c := range []image.Point{l.visible.Min, l.visible.Max.Sub(image.Pt(1, 1))} {
+		assert.True(t, l.cell(c.X, c.Y).In(playArea))
+	}
+}
+
+func TestDrawLargeBoard(t *testing.T) {
+	m := &model.Model{LM: model.NewLevelManager(false), Board: wideLevel(), Zoom: 3}
+	r := newTestRenderer(t, 1)
+	v := NewView(m, r)
+	v.Draw(false)
+
+	// nothing is drawn over the side panel, the player is in sight
+	for y := 0; y < ScreenHeight; y++ {
+		for x := playArea.Max.X; x < 45*CharWidth; x++ {
+			assert.Equal(t, uint8(0), r.Image.RGBAAt(x, y).R)
+		}
+	}
+	assert.True(t, sameAsSprite(r, SpritePlayer, 2, 6))
+}
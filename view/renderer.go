@@ -0,0 +1,85 @@
+package view
+
+import (
+	"image"
+	"image/color"
+	_ "image/png"
+	"os"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+)
+
+// Screen size in screen units, the renderers scale it up
+const (
+	ScreenWidth  = 512
+	ScreenHeight = 256
+	CellSize     = 16 // board cells are square
+	CharWidth    = 8  // text cells, the baseline of a row is at the bottom of its cell
+	LineHeight   = 11
+	fontSize     = 10
+)
+
+// Renderer - Where the view draws: a backend (an OpenGL window, an image in memory...) of a ScreenWidth x ScreenHeight screen, origin top left
+type Renderer interface {
+	Clear(c color.Color)
+	DrawSprite(s Sprite, r image.Rectangle, tint color.Color) // stretched over r (screen units), tinted by multiplying its colours unless tint is nil
+	DrawText(s string, col, row int)                          // in white, from text cell col,row (one row further for each line)
+	Present()                                                 // shows what was drawn since Clear
+}
+
+// SpriteFrames - The frame of each sprite in the spritesheet (image coordinates, origin top left)
+var SpriteFrames = [...]image.Rectangle{
+	SpritePlayer:                   image.Rect(0, 48, 16, 64),
+	SpriteBox:                      image.Rect(16, 48, 32, 64),
+	SpriteGoal:                     image.Rect(32, 48, 48, 64),
+	SpriteWall:                     image.Rect(48, 48, 64, 64),
+	SpriteGoalAndPlayer:            image.Rect(64, 48, 80, 64),
+	SpriteGoalAndBox:               image.Rect(80, 48, 96, 64),
+	SpriteBoxRedCross:              image.Rect(96, 48, 112, 64),
+	SpriteLogo:                     image.Rect(0, 0, 112, 48),
+	SpritePlayerInFreeSpace:        image.Rect(0, 64, 16, 80),
+	SpriteGoalInFreeSpace:          image.Rect(32, 64, 48, 80),
+	SpriteGoalAndPlayerInFreeSpace: image.Rect(64, 64, 80, 80),
+	SpriteFreeSpace:                image.Rect(16, 64, 32, 80),
+	SpriteFreeSpaceBestPath:        image.Rect(80, 64, 96, 80),
+	SpriteGoalInFreeSpaceBestPath:  image.Rect(96, 64, 112, 80),
+	SpriteFree:                     image.Rect(48, 64, 64, 80),
+	SpriteBoxGoUp:                  image.Rect(32, 80, 48, 96),
+	SpriteBoxGoDown:                image.Rect(16, 80, 32, 96),
+	SpriteBoxGoLeft:                image.Rect(48, 80, 64, 96),
+	SpriteBoxGoRight:               image.Rect(0, 80, 16, 96),
+	SpriteBoxShallNotGoUp:          image.Rect(32, 96, 48, 112),
+	SpriteBoxShallNotGoDown:        image.Rect(16, 96, 32, 112),
+	SpriteBoxShallNotGoLeft:        image.Rect(48, 96, 64, 112),
+	SpriteBoxShallNotGoRight:       image.Rect(0, 96, 16, 112),
+	SpriteBoxShallGoUp:             image.Rect(32, 112, 48, 128),
+	SpriteBoxShallGoDown:           image.Rect(16, 112, 32, 128),
+	SpriteBoxShallGoLeft:           image.Rect(48, 112, 64, 128),
+	SpriteBoxShallGoRight:          image.Rect(0, 112, 16, 128),
+}
+
+// LoadSpritesheet - Reads the spritesheet image (see SpriteFrames)
+func LoadSpritesheet(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sheet, _, err := image.Decode(f)
+	return sheet, err
+}
+
+// LoadFont - Reads the TrueType font the text is printed in
+func LoadFont(path string) (*truetype.Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return truetype.Parse(data)
+}
+
+// NewFace - Returns the face the renderers print text in, at the given scale
+func NewFace(ttf *truetype.Font, scale float64) font.Face {
+	return truetype.NewFace(ttf, &truetype.Options{Size: fontSize * scale, GlyphCacheEntries: 1})
+}
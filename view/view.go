@@ -3,25 +3,23 @@ package view
 import (
 	"fmt"
 	"image"
-	_ "image/png"
-	"io/ioutil"
-	"os"
+	"image/color"
 	"golang.org/x/text/message"
 	"golang.org/x/text/language"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/ext/text"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/TheInvader360/sokoban-go/model"
 	"github.com/TheInvader360/sokoban-go/direction"
-	"github.com/golang/freetype/truetype"
 	"golang.org/x/image/colornames"
 )
 
-type spriteIndex int
+// Sprite - A picture of the spritesheet (see SpriteFrames)
+type Sprite int
+
+// deadCellMask - Tints the free space where a box shall never be pushed (see model.Board.DeadCells)
+var deadCellMask = color.RGBA{R: 0xff, G: 0x73, B: 0x73, A: 0xff}
 
 const (
-	SpritePlayer spriteIndex = iota
+	SpritePlayer Sprite = iota
 	SpriteBox
 	SpriteGoal
 	SpriteWall
@@ -51,82 +49,17 @@ const (
 )
 
 type View struct {
-	m           *model.Model
-	win         *opengl.Window
-	scaleFactor float64
-	text        *text.Text
-	sprites     []*pixel.Sprite
+	m      *model.Model
+	r      Renderer
+	layout boardLayout // of the board being drawn
+	camera image.Point // the top left board cell shown of a board larger than the play area
 }
 
-// NewView - Creates a view
-func NewView(m *model.Model, win *opengl.Window, scaleFactor float64) *View {
-	fontFile, err := os.Open("assets/HackJack.ttf")
-	if err != nil {
-		panic(err)
-	}
-	defer fontFile.Close()
-	fontBytes, err := ioutil.ReadAll(fontFile)
-	if err != nil {
-		panic(err)
-	}
-	font, err := truetype.Parse(fontBytes)
-	if err != nil {
-		panic(err)
-	}
-	face := truetype.NewFace(font, &truetype.Options{
-		Size:              10 * scaleFactor,
-		GlyphCacheEntries: 1,
-	})
-	atlas := text.NewAtlas(face, text.ASCII)
-	text := text.New(pixel.V(0, 0), atlas)
-	text.LineHeight = 11 * scaleFactor
-	text.Color = colornames.White
-
-	spritesheetFile, err := os.Open("assets/spritesheet.png")
-	if err != nil {
-		panic(err)
-	}
-	defer spritesheetFile.Close()
-	image, _, err := image.Decode(spritesheetFile)
-	if err != nil {
-		panic(err)
-	}
-	pictureData := pixel.PictureDataFromImage(image)
-
+// NewView - Creates a view drawing the model with the given renderer
+func NewView(m *model.Model, r Renderer) *View {
 	v := View{
-		m:           m,
-		win:         win,
-		scaleFactor: scaleFactor,
-		text:        text,
-		sprites: []*pixel.Sprite{
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(64), float64(16), float64(80))),   // player
-			pixel.NewSprite(pictureData, pixel.R(float64(16), float64(64), float64(32), float64(80))),  // box
-			pixel.NewSprite(pictureData, pixel.R(float64(32), float64(64), float64(48), float64(80))),  // goal
-			pixel.NewSprite(pictureData, pixel.R(float64(48), float64(64), float64(64), float64(80))),  // wall
-			pixel.NewSprite(pictureData, pixel.R(float64(64), float64(64), float64(80), float64(80))),  // goal+player
-			pixel.NewSprite(pictureData, pixel.R(float64(80), float64(64), float64(96), float64(80))),  // goal+box
-			pixel.NewSprite(pictureData, pixel.R(float64(96), float64(64), float64(112), float64(80))),  // box red cross
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(80), float64(112), float64(128))), // logo
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(48), float64(16), float64(64))), // player in freespace
-			pixel.NewSprite(pictureData, pixel.R(float64(32), float64(48), float64(48), float64(64))), // goal in freespace
-			pixel.NewSprite(pictureData, pixel.R(float64(64), float64(48), float64(80), float64(64))), // goal+player in freespace
-			pixel.NewSprite(pictureData, pixel.R(float64(16), float64(48), float64(32), float64(64))), // freespace
-			pixel.NewSprite(pictureData, pixel.R(float64(80), float64(48), float64(96), float64(64))), // freespace best path
-			pixel.NewSprite(pictureData, pixel.R(float64(96), float64(48), float64(112), float64(64))), // goal in freespace best path
-			pixel.NewSprite(pictureData, pixel.R(float64(48), float64(48), float64(64), float64(64))),  // free
-			pixel.NewSprite(pictureData, pixel.R(float64(32), float64(32), float64(48), float64(48))),  // box go up
-			pixel.NewSprite(pictureData, pixel.R(float64(16), float64(32), float64(32), float64(48))),  // box go down
-			pixel.NewSprite(pictureData, pixel.R(float64(48), float64(32), float64(64), float64(48))),  // box go left		
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(32), float64(16), float64(48))),   // box go right
-			pixel.NewSprite(pictureData, pixel.R(float64(32), float64(16), float64(48), float64(32))),  // box shall not go up
-			pixel.NewSprite(pictureData, pixel.R(float64(16), float64(16), float64(32), float64(32))),  // box shall not go down
-			pixel.NewSprite(pictureData, pixel.R(float64(48), float64(16), float64(64), float64(32))),  // box shall not go left		
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(16), float64(16), float64(32))),   // box shall not go right
-			pixel.NewSprite(pictureData, pixel.R(float64(32), float64(0), float64(48), float64(16))),  // box shall go up
-			pixel.NewSprite(pictureData, pixel.R(float64(16), float64(0), float64(32), float64(16))),  // box shall go down
-			pixel.NewSprite(pictureData, pixel.R(float64(48), float64(0), float64(64), float64(16))),  // box shall go left		
-			pixel.NewSprite(pictureData, pixel.R(float64(0), float64(0), float64(16), float64(16))),   // box shall go right
-		},
+		m: m,
+		r: r,
 	}
 
 	return &v
@@ -134,37 +67,40 @@ func NewView(m *model.Model, win *opengl.Window, scaleFactor float64) *View {
 
 // Draw - Draws a graphical representation of the model's current state (called once per main game loop iteration)
 func (v *View) Draw(showFreeSpace bool) {
-	v.win.Clear(colornames.Black)
+	v.r.Clear(colornames.Black)
 
 	v.drawLogoSprite()
 	p := message.NewPrinter(language.English)
 	switch v.m.State {
 	case model.StatePlaying:
-		v.printString(p.Sprintf("Solve Duration : %02d ns", v.m.SolveDuration),0,0)
-		v.printString(p.Sprintf("Boards : %02d", len(v.m.Boards)),0,1)
+		v.drawSearch(p)
+		v.printString(v.m.Notice, 0, 22)
 		v.drawBoard(showFreeSpace)
-		v.printString(fmt.Sprintf("Level %02d of %02d", v.m.LM.GetCurrentLevelNumber(), v.m.LM.GetFinalLevelNumber()), 45, 7)
-		v.printString(fmt.Sprintf("Moves %02d/%02d/%02d", v.m.Moves, v.m.BestMoves, v.m.Moves+v.m.Board.GetBestPosition().BestLength), 45, 9)
-		v.printString("---Controls---\n\nCursors:  Move\nA:    AutoMove\nF:  Show Hints\nZ:        Undo\nR:       Reset\nEscape:   Quit", 46, 12)
+		v.printString(fmt.Sprintf("Level %02d of %02d", v.m.LM.GetCurrentLevelNumber(), v.m.LM.GetFinalLevelNumber()), 45, 6)
+		v.printString(fmt.Sprintf("Moves %02d/%02d/%02d", v.m.Moves, v.m.BestMoves, v.m.Moves+v.m.Board.GetBestPosition().BestLength), 45, 8)
+		v.printString(v.objective(), 45, 7)
+		v.printString(fmt.Sprintf("Hints %9s", v.m.Hints), 45, 9)
+		v.printString("---Controls---\n\nCursors:  Move\nA:    AutoMove\nF:  Show Hints\nB: Hint Solver\nO:   Objective\nZ:        Undo\nY:        Redo\nR:       Reset\nL:  Load Moves\n+/-:      Zoom\nEscape:   Quit", 46, 10)
 	case model.StateLevelComplete:
-		v.printString(p.Sprintf("Solve Duration : %02d ns", v.m.SolveDuration),0,0)
-		v.printString(p.Sprintf("Boards : %02d", len(v.m.Boards)),0,1)
+		v.drawSearch(p)
 		v.drawBoard(showFreeSpace)
-		v.printString(fmt.Sprintf("Level %02d of %02d", v.m.LM.GetCurrentLevelNumber(), v.m.LM.GetFinalLevelNumber()), 45, 7)
-		v.printString(fmt.Sprintf("Moves %02d/%02d/%02d", v.m.Moves, v.m.BestMoves, v.m.Moves+v.m.Board.GetBestPosition().BestLength), 45, 9)
+		v.printString(fmt.Sprintf("Level %02d of %02d", v.m.LM.GetCurrentLevelNumber(), v.m.LM.GetFinalLevelNumber()), 45, 6)
+		v.printString(v.objective(), 45, 7)
+		v.printString(fmt.Sprintf("Moves %02d/%02d/%02d", v.m.Moves, v.m.BestMoves, v.m.Moves+v.m.Board.GetBestPosition().BestLength), 45, 8)
 		if v.m.TickAccumulator < 10 {
 			v.printString("LEVEL COMPLETE", 45, 12)
 		}
-		v.printString("---Controls---\n\nSpace:    Next\n              \nEscape:   Quit", 45, 14)
+		v.printString("---Controls---\n\nSpace:    Next\nS:  Save Moves\nEscape:   Quit", 45, 14)
 	case model.StateGameComplete:
 		v.printString("GAME COMPLETE!", 16, 10)
 		if v.m.TickAccumulator < 10 {
 			v.printString("CONGRATULATIONS!", 15, 12)
 		} else {
+			v.layout = boardLayout{origin: playArea.Min, tile: CellSize}
 			for y := 0; y <= 13; y++ {
 				for x := 0; x <= 21; x++ {
 					if x == 0 || x == 21 || y == 0 || y == 13 {
-						v.drawBoardSprite(SpritePlayer, float64(x), float64(y), float64(1), float64(1))
+						v.drawBoardSprite(SpritePlayer, x, y)
 					}
 				}
 			}
@@ -172,89 +108,124 @@ func (v *View) Draw(showFreeSpace bool) {
 		v.printString("---Controls---\n\nSpace: Restart\n              \nEscape:   Quit", 45, 14)
 	}
 
-	v.win.Update()
+	v.r.Present()
+}
+
+// objective - Returns what the best moves are the fewest of: the solver objective, or roughly moves for the board search
+func (v *View) objective() string {
+	if v.m.Hints == model.HintBackendSolver {
+		return fmt.Sprintf("Fewest %8s", v.m.Objective)
+	}
+	return fmt.Sprintf("Fewest %8s", "~Moves")
+}
+
+// drawSearch - Prints the hint search status (progress while it runs) in the top left corner
+func (v *View) drawSearch(p *message.Printer) {
+	switch {
+	case v.m.Search.Running:
+		v.printString(p.Sprintf("Searching... bound %d", v.m.Search.Bound),0,0)
+	case v.m.Search.Expired:
+		v.printString("No hint available",0,0)
+	default:
+		v.printString(p.Sprintf("Solve Duration : %02d ns", v.m.SolveDuration),0,0)
+	}
+	if t := v.m.Search.Table; t.Hits+t.Misses > 0 {
+		v.printString(p.Sprintf("Boards : %02d  Hits : %d%%  %d MB", v.m.Search.Explored, 100*t.Hits/(t.Hits+t.Misses), t.Bytes>>20),0,1)
+	} else {
+		v.printString(p.Sprintf("Boards : %02d", v.m.Search.Explored),0,1)
+	}
 }
 
-func (v *View) drawArrowsDir(box *model.Box,x, y, boardOffsetX, boardOffsetY int, dir direction.Direction) {
+func (v *View) drawArrowsDir(box *model.Box,x, y int, dir direction.Direction) {
 	if box.CanMove[dir] { 
-		if box.ShallNotMove[dir] { v.drawBoardSprite(SpriteBoxShallNotGoUp+spriteIndex(dir), float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY)) 
+		if box.ShallNotMove[dir] { v.drawBoardSprite(SpriteBoxShallNotGoUp+Sprite(dir), x, y) 
 		} else { 
 			if v.m.Board.GetBestPosition().BestX!=x || v.m.Board.GetBestPosition().BestY!=y || v.m.Board.GetBestPosition().BestDir != dir {
-				v.drawBoardSprite(SpriteBoxShallGoUp+spriteIndex(dir), float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-			} else { v.drawBoardSprite(SpriteBoxGoUp+spriteIndex(dir), float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY)) }
+				v.drawBoardSprite(SpriteBoxShallGoUp+Sprite(dir), x, y)
+			} else { v.drawBoardSprite(SpriteBoxGoUp+Sprite(dir), x, y) }
 		}
 	}
 }
 
-func (v *View) drawArrows(cell *model.Cell,x, y, boardOffsetX, boardOffsetY int) {
+func (v *View) drawArrows(cell *model.Cell,x, y int) {
 	if !cell.HasBox { return }
 	box := v.m.Board.Boxes[cell.Box]
-	v.drawArrowsDir(&box,x,y,boardOffsetX,boardOffsetY,direction.U)
-	v.drawArrowsDir(&box,x,y,boardOffsetX,boardOffsetY,direction.D)
-	v.drawArrowsDir(&box,x,y,boardOffsetX,boardOffsetY,direction.L)
-	v.drawArrowsDir(&box,x,y,boardOffsetX,boardOffsetY,direction.R)
+	v.drawArrowsDir(&box,x,y,direction.U)
+	v.drawArrowsDir(&box,x,y,direction.D)
+	v.drawArrowsDir(&box,x,y,direction.L)
+	v.drawArrowsDir(&box,x,y,direction.R)
 }
 
+// drawBoard - Draws the board in the play area, fitted, zoomed and scrolled (see layoutBoard)
 func (v *View) drawBoard(showFreeSpace bool) {
 	if v.m.State != model.StateGameComplete {
-		boardOffsetX := ((22 - v.m.Board.Width) / 2) + 1
-		boardOffsetY := ((14 - v.m.Board.Height) / 2) + 1
-		for y := 0; y < v.m.Board.Height; y++ {
-			for x := 0; x < v.m.Board.Width; x++ {
-				cell := v.m.Board.Get(x, y)
-				switch cell.TypeOf {
-				case model.CellTypeNone:
-					if cell.HasBox {
-						if showFreeSpace && v.m.Board.Boxes[cell.Box].IsDead { v.drawBoardSprite(SpriteBoxRedCross, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						} else { v.drawBoardSprite(SpriteBox, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY)) }
-						if showFreeSpace { v.drawArrows(cell,x,y,boardOffsetX,boardOffsetY) }
-					} else if showFreeSpace && cell.IsFree {
-						if cell.IsPath { v.drawBoardSprite(SpriteFreeSpaceBestPath, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						} else { v.drawBoardSprite(SpriteFreeSpace, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY)) }
+		v.drawBoardAt(showFreeSpace, v.layoutBoard(v.m.Board))
+	}
+}
+
+// drawBoardAt - Draws the visible cells of the board where the layout puts them
+func (v *View) drawBoardAt(showFreeSpace bool, l boardLayout) {
+	v.layout = l
+	for y := l.visible.Min.Y; y < l.visible.Max.Y; y++ {
+		for x := l.visible.Min.X; x < l.visible.Max.X; x++ {
+			cell := v.m.Board.Get(x, y)
+			switch cell.TypeOf {
+			case model.CellTypeNone:
+				if cell.HasBox {
+					if showFreeSpace && v.m.Board.Boxes[cell.Box].IsDead { v.drawBoardSprite(SpriteBoxRedCross, x, y)
+					} else { v.drawBoardSprite(SpriteBox, x, y) }
+					if showFreeSpace { v.drawArrows(cell,x,y) }
+				} else if showFreeSpace && cell.IsFree {
+					if cell.IsPath { v.drawBoardSprite(SpriteFreeSpaceBestPath, x, y)
+					} else if v.m.Board.IsDeadCell(x,y) { v.drawBoardSpriteMasked(SpriteFreeSpace, x, y, deadCellMask)
+					} else { v.drawBoardSprite(SpriteFreeSpace, x, y) }
+				} else {
+					v.drawBoardSprite(SpriteFree, x, y)
+				}
+			case model.CellTypeGoal:
+				if cell.HasBox {
+					v.drawBoardSprite(SpriteGoalAndBox, x, y)
+					if showFreeSpace { v.drawArrows(cell,x,y) }
+
+				} else if v.m.Board.Player.X == x && v.m.Board.Player.Y == y {
+					if showFreeSpace && cell.IsFree {
+						v.drawBoardSprite(SpriteGoalAndPlayerInFreeSpace, x, y)
 					} else {
-						v.drawBoardSprite(SpriteFree, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
+						v.drawBoardSprite(SpriteGoalAndPlayer, x, y)
 					}
-				case model.CellTypeGoal:
-					if cell.HasBox {
-						v.drawBoardSprite(SpriteGoalAndBox, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						if showFreeSpace { v.drawArrows(cell,x,y,boardOffsetX,boardOffsetY) }
-
-					} else if v.m.Board.Player.X == x && v.m.Board.Player.Y == y {
-						if showFreeSpace && cell.IsFree {
-							v.drawBoardSprite(SpriteGoalAndPlayerInFreeSpace, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						} else {
-							v.drawBoardSprite(SpriteGoalAndPlayer, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						}
+				} else {
+					if showFreeSpace && cell.IsFree {
+						if cell.IsPath { v.drawBoardSprite(SpriteGoalInFreeSpaceBestPath, x, y)
+						} else { v.drawBoardSprite(SpriteGoalInFreeSpace, x, y) }
 					} else {
-						if showFreeSpace && cell.IsFree {
-							if cell.IsPath { v.drawBoardSprite(SpriteGoalInFreeSpaceBestPath, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-							} else { v.drawBoardSprite(SpriteGoalInFreeSpace, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY)) }
-						} else {
-							v.drawBoardSprite(SpriteGoal, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
-						}
+						v.drawBoardSprite(SpriteGoal, x, y)
 					}
-				case model.CellTypeWall:
-					v.drawBoardSprite(SpriteWall, float64(x), float64(y), float64(boardOffsetX), float64(boardOffsetY))
 				}
+			case model.CellTypeWall:
+				v.drawBoardSprite(SpriteWall, x, y)
 			}
 		}
-		v.drawBoardSprite(SpritePlayer, float64(v.m.Board.Player.X), float64(v.m.Board.Player.Y), float64(boardOffsetX), float64(boardOffsetY))
+	}
+	if l.shows(v.m.Board.Player.X, v.m.Board.Player.Y) {
+		v.drawBoardSprite(SpritePlayer, v.m.Board.Player.X, v.m.Board.Player.Y)
 	}
 }
 
 func (v *View) drawLogoSprite() {
-	r := pixel.R(float64(360)*v.scaleFactor, v.win.Bounds().H()-float64(48)*v.scaleFactor, float64(496)*v.scaleFactor, v.win.Bounds().H()-float64(0)*v.scaleFactor)
-	v.sprites[SpriteLogo].Draw(v.win, pixel.IM.ScaledXY(pixel.ZV, pixel.V(r.W()/v.sprites[SpriteLogo].Frame().W(), r.H()/v.sprites[SpriteLogo].Frame().H())).Moved(r.Center()))
+	v.r.DrawSprite(SpriteLogo, image.Rect(360, 0, 496, 48), nil)
+}
+
+// drawBoardSprite - Draws a sprite over board cell x,y of the current layout
+func (v *View) drawBoardSprite(s Sprite, x, y int) {
+	v.r.DrawSprite(s, v.layout.cell(x, y), nil)
 }
 
-func (v *View) drawBoardSprite(s spriteIndex, x, y, offsetX, offsetY float64) {
-	r := pixel.R((offsetX+x)*16*v.scaleFactor, v.win.Bounds().H()-(offsetY+y+1)*16*v.scaleFactor, (offsetX+x+1)*16*v.scaleFactor, v.win.Bounds().H()-(offsetY+y)*16*v.scaleFactor)
-	v.sprites[s].Draw(v.win, pixel.IM.ScaledXY(pixel.ZV, pixel.V(r.W()/v.sprites[s].Frame().W(), r.H()/v.sprites[s].Frame().H())).Moved(r.Center()))
+// drawBoardSpriteMasked - Draws a board sprite tinted by a color mask
+func (v *View) drawBoardSpriteMasked(s Sprite, x, y int, mask color.Color) {
+	v.r.DrawSprite(s, v.layout.cell(x, y), mask)
 }
 
 // printString - prints the given string at screen position x,y (i.e. 0-63,0-22)
 func (v *View) printString(s string, x, y int) {
-	v.text.Clear()
-	v.text.WriteString(s)
-	v.text.Draw(v.win, pixel.IM.Moved(pixel.V(float64(x*8)*v.scaleFactor, (245-float64(y*11))*v.scaleFactor)))
+	v.r.DrawText(s, x, y)
 }
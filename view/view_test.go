@@ -0,0 +1,95 @@
+package view
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TheInvader360/sokoban-go/controller"
+	"github.com/TheInvader360/sokoban-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRenderer - Returns an image renderer with the game assets
+func newTestRenderer(t *testing.T, scale int) *ImageRenderer {
+	sheet, err := LoadSpritesheet("../assets/spritesheet.png")
+	assert.NoError(t, err)
+	ttf, err := LoadFont("../assets/HackJack.ttf")
+	assert.NoError(t, err)
+	return NewImageRenderer(scale, sheet, ttf)
+}
+
+// sameAsSprite - Returns true if the screen cell at col,row holds the sprite, as drawn on black
+func sameAsSprite(r *ImageRenderer, s Sprite, col, row int) bool {
+	frame := SpriteFrames[s]
+	for y := 0; y < CellSize*r.scale; y++ {
+		for x := 0; x < CellSize*r.scale; x++ {
+			want := color.RGBAModel.Convert(r.sheet.At(frame.Min.X+x/r.scale, frame.Min.Y+y/r.scale)).(color.RGBA)
+			got := r.Image.RGBAAt(col*CellSize*r.scale+x, row*CellSize*r.scale+y)
+			if want.A == 0xff && want != got {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestDraw(t *testing.T) {
+	m := model.Model{LM: model.NewLevelManager(false), Boards: model.NewTable(0)}
+	c := controller.NewController(&m)
+	c.StartNewGame()
+	c.WaitForHints()
+	r := newTestRenderer(t, 2)
+	v := NewView(&m, r)
+
+	// level 1 is 8x8, drawn from board cell 8,4
+	v.Draw(false)
+	assert.Equal(t, 1, r.Frames)
+	assert.Equal(t, image.Rect(0, 0, 1024, 512), r.Image.Bounds())
+	assert.True(t, sameAsSprite(r, SpriteWall, 8+2, 4))
+	assert.True(t, sameAsSprite(r, SpriteGoal, 8+3, 4+1))
+	assert.True(t, sameAsSprite(r, SpritePlayer, 8+4, 4+4))
+	assert.True(t, sameAsSprite(r, SpriteBox, 8+5, 4+3))
+	assert.True(t, sameAsSprite(r, SpriteFree, 8+4, 4+3))
+
+	// the hints: free space and arrows over the boxes
+	v.Draw(true)
+	assert.True(t, sameAsSprite(r, SpriteFreeSpace, 8+4, 4+3))
+	assert.False(t, sameAsSprite(r, SpriteBox, 8+5, 4+3))
+
+	// the text is white on black, nothing is left of the previous frame
+	white, black := 0, 0
+	for y := 7 * LineHeight * 2; y < 8*LineHeight*2; y++ {
+		for x := 45 * CharWidth * 2; x < 59*CharWidth*2; x++ {
+			switch r.Image.RGBAAt(x, y) {
+			case color.RGBA{0xff, 0xff, 0xff, 0xff}:
+				white++
+			case color.RGBA{0, 0, 0, 0xff}:
+				black++
+			}
+		}
+	}
+	assert.Greater(t, white, 100)
+	assert.Greater(t, black, white)
+	m.State = model.StateGameComplete
+	m.TickAccumulator = 10
+	v.Draw(false)
+	assert.Equal(t, 3, r.Frames)
+	assert.True(t, sameAsSprite(r, SpritePlayer, 1, 1))
+	assert.False(t, sameAsSprite(r, SpriteWall, 8+2, 4))
+}
+
+func TestImageRendererTint(t *testing.T) {
+	r := newTestRenderer(t, 1)
+	r.Clear(color.Black)
+	r.DrawSprite(SpriteFreeSpace, image.Rect(0, 0, 16, 16), nil)
+	r.DrawSprite(SpriteFreeSpace, image.Rect(16, 0, 32, 16), deadCellMask)
+	r.DrawSprite(SpriteFreeSpace, image.Rect(32, 0, 64, 32), nil)
+	plain, tinted := r.Image.RGBAAt(8, 8), r.Image.RGBAAt(24, 8)
+	assert.Equal(t, plain.R, tinted.R)
+	assert.Less(t, tinted.G, plain.G)
+	assert.Equal(t, plain, r.Image.RGBAAt(48, 16))
+
+	// drawing off the screen is clipped
+	r.DrawSprite(SpriteWall, image.Rect(ScreenWidth-8, ScreenHeight-8, ScreenWidth+8, ScreenHeight+8), nil)
+}